@@ -0,0 +1,276 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// moduleDir 编译检查使用的模块文件（go.mod/go.sum），固定生成代码依赖的版本
+const moduleDir = "testdata/module"
+
+// storageStub 生成代码引用的 storage/mysql 包，只需暴露 DB
+const storageStub = `package mysql
+
+import "gorm.io/gorm"
+
+// DB 默认数据库连接
+var DB *gorm.DB
+`
+
+// typesStub json_types 映射的自定义 JSON 类型
+const typesStub = `package types
+
+// ArticleMeta 文章元数据
+type ArticleMeta struct {
+	Source string   ` + "`json:\"source\"`" + `
+	Tags   []string ` + "`json:\"tags\"`" + `
+}
+`
+
+// testPackages 编译检查的 Model、Service、Router 包
+var testPackages = []string{"./internal/models/...", "./internal/services/...", "./internal/router/..."}
+
+// TestGeneratedCodeCompiles 在临时模块中生成全部代码：Model、Service、Router 及其测试执行 go build 与 go vet；
+// .proto 文件用 protocompile 编译，GraphQL schema 用 gqlparser 校验。
+// PATH 中有 protoc-gen-go 与 protoc-gen-go-grpc 时生成 pb 代码并编译 gRPC 服务端，能运行 gqlgen 时生成执行代码并编译 GraphQL 解析器，
+// 否则这两部分跳过编译；TypeScript 代码不做检查。需要下载依赖，-short 时跳过。
+func TestGeneratedCodeCompiles(t *testing.T) {
+	goBin := lookupGo(t)
+	root := newTestModule(t)
+	cfg := testFullConfig(root)
+	generateTestTree(t, cfg)
+	files := checkProtoFiles(t, cfg.ProtoOutput)
+	checkGraphQLSchema(t, filepath.Join(cfg.GraphQLOutput, "schema"))
+
+	packages := testPackages
+	if generateProtoGo(t, files, cfg.ProtoOutput) {
+		packages = append(packages, "./internal/pb/...", "./internal/grpcserver/...")
+	}
+	if runGQLGen(t, goBin, cfg.GraphQLOutput) {
+		packages = append(packages, "./internal/graph/...")
+	}
+	buildTestModule(t, goBin, root, packages...)
+}
+
+// TestSkipContainerCompiles 不生成 Service 容器时，Router 不再引用 services.Repos 且仍能编译
+func TestSkipContainerCompiles(t *testing.T) {
+	goBin := lookupGo(t)
+	root := newTestModule(t)
+	cfg := testConfig(root)
+	cfg.SkipContainer = true
+	generateTestTree(t, cfg)
+	if _, err := os.Stat(filepath.Join(cfg.ServiceOutput, "services.go")); !os.IsNotExist(err) {
+		t.Fatalf("SkipContainer 时不应生成 services.go: %v", err)
+	}
+	buildTestModule(t, goBin, root, testPackages...)
+}
+
+// lookupGo 获取 go 命令路径，-short 或找不到 go 命令时跳过测试
+func lookupGo(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("-short 模式跳过编译检查")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("未找到 go 命令，跳过编译检查")
+	}
+	return goBin
+}
+
+// settingsStub 导入路径带版本后缀（settings.v1）的自定义 JSON 类型，包名为 settings
+const settingsStub = `package settings
+
+// Settings 文章设置
+type Settings struct {
+	Theme string ` + "`json:\"theme\"`" + `
+}
+`
+
+// toolsStub 固定 gqlgen 命令依赖版本的 tools 包，仅在 tools 构建标签下编译
+const toolsStub = `//go:build tools
+
+package tools
+
+import _ "github.com/99designs/gqlgen"
+`
+
+// newTestModule 创建临时模块，写入固定依赖版本的 go.mod/go.sum 与生成代码引用的桩包
+func newTestModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filepath.Join(moduleDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stubs := map[string]string{
+		filepath.Join("internal", "storage", "mysql", "mysql.go"): storageStub,
+		filepath.Join("internal", "types", "types.go"):            typesStub,
+		filepath.Join("internal", "settings.v1", "settings.go"):   settingsStub,
+		filepath.Join("internal", "tools", "tools.go"):            toolsStub,
+	}
+	for name, content := range stubs {
+		dir := filepath.Join(root, filepath.Dir(name))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// buildTestModule 对生成的包执行 go build 与 go vet，go vet 会同时编译生成的 _test.go
+func buildTestModule(t *testing.T, goBin, root string, packages ...string) {
+	t.Helper()
+	for _, command := range []string{"build", "vet"} {
+		args := append([]string{command}, packages...)
+		cmd := exec.Command(goBin, args...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=readonly")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s 失败: %v\n%s", args[0], err, out)
+		}
+	}
+}
+
+// checkProtoFiles 编译目录下全部 .proto 文件，包括字段 JSON 名冲突等 protoc 会拒绝的错误，返回编译结果
+func checkProtoFiles(t *testing.T, dir string) linker.Files {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.proto"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("未找到生成的 .proto 文件: %v", err)
+	}
+	for i, file := range files {
+		files[i] = filepath.Base(file)
+	}
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{dir}}),
+	}
+	result, err := compiler.Compile(context.Background(), files...)
+	if err != nil {
+		t.Fatalf("编译 .proto 失败: %v", err)
+	}
+	return result
+}
+
+// generateProtoGo 按 protoc 插件协议调用 protoc-gen-go 与 protoc-gen-go-grpc，将 pb 代码写入 dir；
+// 找不到插件时返回 false
+func generateProtoGo(t *testing.T, files linker.Files, dir string) bool {
+	t.Helper()
+	plugins := []string{"protoc-gen-go", "protoc-gen-go-grpc"}
+	for i, name := range plugins {
+		bin, err := exec.LookPath(name)
+		if err != nil {
+			t.Logf("未找到 %s，跳过 gRPC 代码编译检查", name)
+			return false
+		}
+		plugins[i] = bin
+	}
+
+	// ProtoFile 需按依赖顺序列出待生成文件及其全部导入
+	req := &pluginpb.CodeGeneratorRequest{Parameter: proto.String("paths=source_relative")}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, file := range files {
+		add(file)
+		req.FileToGenerate = append(req.FileToGenerate, file.Path())
+	}
+	input, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bin := range plugins {
+		cmd := exec.Command(bin)
+		cmd.Stdin = bytes.NewReader(input)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%s 失败: %v\n%s", filepath.Base(bin), err, stderr.Bytes())
+		}
+		var resp pluginpb.CodeGeneratorResponse
+		if err := proto.Unmarshal(output, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Error != nil {
+			t.Fatalf("%s 失败: %s", filepath.Base(bin), resp.GetError())
+		}
+		for _, f := range resp.File {
+			if err := os.WriteFile(filepath.Join(dir, f.GetName()), []byte(f.GetContent()), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return true
+}
+
+// runGQLGen 在 GraphQL 输出目录执行 gqlgen generate 生成执行代码；无法运行 gqlgen（如依赖无法下载）时返回 false
+func runGQLGen(t *testing.T, goBin, dir string) bool {
+	t.Helper()
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.Command(goBin, append([]string{"run", "github.com/99designs/gqlgen"}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=readonly")
+		return cmd.CombinedOutput()
+	}
+	if out, err := run("version"); err != nil {
+		t.Logf("无法运行 gqlgen，跳过 GraphQL 代码编译检查: %v\n%s", err, out)
+		return false
+	}
+	if out, err := run("generate"); err != nil {
+		t.Fatalf("gqlgen generate 失败: %v\n%s", err, out)
+	}
+	return true
+}
+
+// checkGraphQLSchema 加载目录下全部 .graphqls 文件并校验 schema
+func checkGraphQLSchema(t *testing.T, dir string) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.graphqls"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("未找到生成的 GraphQL schema: %v", err)
+	}
+	var sources []*ast.Source
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources = append(sources, &ast.Source{Name: filepath.Base(file), Input: string(data)})
+	}
+	if _, err := gqlparser.LoadSchema(sources...); err != nil {
+		t.Fatalf("校验 GraphQL schema 失败: %v", err)
+	}
+}
@@ -9,13 +9,27 @@ import (
 
 // ConfigFile 配置文件结构
 type ConfigFile struct {
-	Database DatabaseConfig `yaml:"database"`
-	Output   OutputConfig   `yaml:"output"`
-	Tables   string         `yaml:"tables,omitempty"`
-	Options  OptionsConfig  `yaml:"options"`
-	Router   RouterConfig   `yaml:"router"`
-	Service  ServiceConfig  `yaml:"service"`
-	Imports  ImportConfig   `yaml:"imports"`
+	Database  DatabaseConfig      `yaml:"database"`
+	Output    OutputConfig        `yaml:"output"`
+	Tables    string              `yaml:"tables,omitempty"`
+	Include   []string            `yaml:"include"`
+	Exclude   []string            `yaml:"exclude"`
+	Views     string              `yaml:"views"`
+	ViewKeys  map[string][]string `yaml:"view_keys"`
+	JSONTypes map[string]string   `yaml:"json_types"`
+	Options   OptionsConfig       `yaml:"options"`
+	Router    RouterConfig        `yaml:"router"`
+	Service   ServiceConfig       `yaml:"service"`
+	Imports   ImportConfig        `yaml:"imports"`
+	List      ListConfig          `yaml:"list"`
+	Search    SearchConfig        `yaml:"search"`
+	Lock      LockConfig          `yaml:"optimistic_lock"`
+	Tenant    TenantConfig        `yaml:"tenant"`
+	GRPC      GRPCConfig          `yaml:"grpc"`
+	GraphQL   GraphQLConfig       `yaml:"graphql"`
+	TS        TSConfig            `yaml:"typescript"`
+	Naming    NamingConfig        `yaml:"naming"`
+	Sharding  ShardingConfig      `yaml:"sharding"`
 }
 
 // DatabaseConfig 数据库配置
@@ -35,13 +49,16 @@ type OutputConfig struct {
 
 // OptionsConfig 生成选项
 type OptionsConfig struct {
-	GenerateBaseModel   bool `yaml:"generate_base_model"`
-	UseSoftDelete       bool `yaml:"use_soft_delete"`
-	GenerateJSONTags    bool `yaml:"generate_json_tags"`
-	GenerateGORMTags    bool `yaml:"generate_gorm_tags"`
-	GenerateComments    bool `yaml:"generate_comments"`
-	GenerateRouter      bool `yaml:"generate_router"`
-	GenerateService     bool `yaml:"generate_service"`
+	GenerateBaseModel bool `yaml:"generate_base_model"`
+	UseSoftDelete     bool `yaml:"use_soft_delete"`
+	GenerateJSONTags  bool `yaml:"generate_json_tags"`
+	GenerateGORMTags  bool `yaml:"generate_gorm_tags"`
+	GenerateComments  bool `yaml:"generate_comments"`
+	GenerateRouter    bool `yaml:"generate_router"`
+	GenerateService   bool `yaml:"generate_service"`
+	GenerateGRPC      bool `yaml:"generate_grpc"`
+	GenerateGraphQL   bool `yaml:"generate_graphql"`
+	GenerateTests     bool `yaml:"generate_tests"`
 }
 
 // ImportConfig 导入路径配置
@@ -49,16 +66,100 @@ type ImportConfig struct {
 	Model   string `yaml:"model"`
 	Service string `yaml:"service"`
 	Storage string `yaml:"storage"`
+	// Proto protoc 生成的 Go 代码导入路径
+	Proto string `yaml:"proto"`
+	// GraphQL GraphQL 解析器包导入路径
+	GraphQL string `yaml:"graphql"`
 }
 
 // RouterConfig Router配置
 type RouterConfig struct {
 	Output string `yaml:"output"`
+	// StatusMode 错误响应的 HTTP 状态码策略: envelope（默认，始终返回 200）或 http
+	StatusMode string `yaml:"status_mode"`
+	// Operations 按表名配置生成的路由操作，可用 read/write/all 别名
+	Operations map[string][]string `yaml:"operations"`
+	// PermissionFormat 路由权限名格式，默认 {table}:{op}
+	PermissionFormat string `yaml:"permission_format"`
+}
+
+// GRPCConfig gRPC 配置
+type GRPCConfig struct {
+	// Output gRPC 服务端代码输出目录
+	Output string `yaml:"output"`
+	// ProtoOutput .proto 文件输出目录
+	ProtoOutput string `yaml:"proto_output"`
+	// ProtoPackage .proto 文件的 package
+	ProtoPackage string `yaml:"proto_package"`
+}
+
+// GraphQLConfig GraphQL 配置
+type GraphQLConfig struct {
+	// Output schema、gqlgen.yml 与解析器的输出目录
+	Output string `yaml:"output"`
+}
+
+// TSConfig TypeScript 配置
+type TSConfig struct {
+	// Output 类型定义与接口客户端输出目录，为空时不生成
+	Output string `yaml:"output"`
+}
+
+// NamingConfig 命名配置
+type NamingConfig struct {
+	// Initialisms 额外的缩写词，生成 Go 名称时整体大写，如 SKU、OTP
+	Initialisms []string `yaml:"initialisms"`
+	// StripPrefixes 推导结构体名、文件名与路由路径前去除的表名前缀，如 t_、tb_
+	StripPrefixes []string `yaml:"strip_prefixes"`
+	// Tables 按表名覆盖结构体名、文件名与路由路径
+	Tables map[string]TableNaming `yaml:"tables"`
+	// JSONCase 模型 JSON 标签的命名风格: snake（默认）、camel 或 original（与列名相同）
+	JSONCase string `yaml:"json_case"`
+	// RouteCase 默认路由路径的命名风格: snake（默认）或 kebab
+	RouteCase string `yaml:"route_case"`
+}
+
+// ShardingConfig 分表配置
+type ShardingConfig struct {
+	// Patterns 分表规则，正则的命名分组 name（没有时为第一个分组）捕获逻辑表名，如 ^(order)_\d+$
+	Patterns []string `yaml:"patterns"`
 }
 
 // ServiceConfig Service配置
 type ServiceConfig struct {
 	Output string `yaml:"output"`
+	// SkipContainer 不生成 Service 容器 services.go（Repos、NewRepos 与 Transaction）
+	SkipContainer bool `yaml:"skip_container"`
+}
+
+// ListConfig 列表接口配置
+type ListConfig struct {
+	// SortableColumns 按表名配置额外允许排序的字段，索引字段默认可排序
+	SortableColumns map[string][]string `yaml:"sortable_columns"`
+	// Pagination 分页模式: offset（默认）或 cursor
+	Pagination string `yaml:"pagination"`
+	// CursorColumns 按表名配置游标分页使用的索引字段，未配置时使用主键
+	CursorColumns map[string]string `yaml:"cursor_columns"`
+}
+
+// SearchConfig 搜索接口配置
+type SearchConfig struct {
+	// Columns 按表名配置搜索字段，未配置时优先使用全文索引字段，其次为带索引的字符串字段
+	Columns map[string][]string `yaml:"columns"`
+}
+
+// LockConfig 乐观锁配置
+type LockConfig struct {
+	// Columns 版本字段名，表中存在其中任一字段时启用乐观锁，如 version、lock_version
+	Columns []string `yaml:"columns"`
+}
+
+// TenantConfig 多租户配置
+type TenantConfig struct {
+	// Column 租户字段名，如 tenant_id；为空时不启用多租户
+	Column string `yaml:"column"`
+	// ContextKey Router 从 gin 上下文读取租户 ID 的键，默认与 Column 相同
+	ContextKey string `yaml:"context_key"`
 }
 
 // LoadConfig 加载配置文件
@@ -83,21 +184,53 @@ func LoadConfig(configPath string) (*ConfigFile, error) {
 // MergeConfig 合并命令行参数和配置文件
 func MergeConfig(cmdConfig *Config, fileConfig *ConfigFile) *Config {
 	result := &Config{
-		Host:            cmdConfig.Host,
-		Port:            cmdConfig.Port,
-		User:            cmdConfig.User,
-		Password:        cmdConfig.Password,
-		Database:        cmdConfig.Database,
-		Output:          cmdConfig.Output,
-		Package:         cmdConfig.Package,
-		Tables:          cmdConfig.Tables,
-		GenerateRouter:  cmdConfig.GenerateRouter,
-		GenerateService: cmdConfig.GenerateService,
-		RouterOutput:    cmdConfig.RouterOutput,
-		ServiceOutput:   cmdConfig.ServiceOutput,
+		Host:              cmdConfig.Host,
+		Port:              cmdConfig.Port,
+		User:              cmdConfig.User,
+		Password:          cmdConfig.Password,
+		Database:          cmdConfig.Database,
+		Output:            cmdConfig.Output,
+		Package:           cmdConfig.Package,
+		Tables:            cmdConfig.Tables,
+		Include:           cmdConfig.Include,
+		Exclude:           cmdConfig.Exclude,
+		Views:             cmdConfig.Views,
+		GenerateRouter:    cmdConfig.GenerateRouter,
+		GenerateService:   cmdConfig.GenerateService,
+		RouterOutput:      cmdConfig.RouterOutput,
+		ServiceOutput:     cmdConfig.ServiceOutput,
 		ModelImportPath:   cmdConfig.ModelImportPath,
 		ServiceImportPath: cmdConfig.ServiceImportPath,
 		StorageImportPath: cmdConfig.StorageImportPath,
+		SortableColumns:   cmdConfig.SortableColumns,
+		Pagination:        cmdConfig.Pagination,
+		CursorColumns:     cmdConfig.CursorColumns,
+		SearchColumns:     cmdConfig.SearchColumns,
+		VersionColumns:    cmdConfig.VersionColumns,
+		TenantColumn:      cmdConfig.TenantColumn,
+		TenantContextKey:  cmdConfig.TenantContextKey,
+		StatusMode:        cmdConfig.StatusMode,
+		Operations:        cmdConfig.Operations,
+		PermissionFormat:  cmdConfig.PermissionFormat,
+		GenerateGRPC:      cmdConfig.GenerateGRPC,
+		GRPCOutput:        cmdConfig.GRPCOutput,
+		ProtoOutput:       cmdConfig.ProtoOutput,
+		ProtoPackage:      cmdConfig.ProtoPackage,
+		ProtoImportPath:   cmdConfig.ProtoImportPath,
+		GenerateGraphQL:   cmdConfig.GenerateGraphQL,
+		GraphQLOutput:     cmdConfig.GraphQLOutput,
+		GraphQLImportPath: cmdConfig.GraphQLImportPath,
+		TSOutput:          cmdConfig.TSOutput,
+		GenerateTests:     cmdConfig.GenerateTests,
+		SkipContainer:     cmdConfig.SkipContainer,
+		Initialisms:       cmdConfig.Initialisms,
+		TablePrefixes:     cmdConfig.TablePrefixes,
+		TableNaming:       cmdConfig.TableNaming,
+		JSONCase:          cmdConfig.JSONCase,
+		RouteCase:         cmdConfig.RouteCase,
+		ShardPatterns:     cmdConfig.ShardPatterns,
+		ViewKeys:          cmdConfig.ViewKeys,
+		JSONTypes:         cmdConfig.JSONTypes,
 	}
 
 	// 如果命令行参数为空，使用配置文件的值
@@ -125,6 +258,15 @@ func MergeConfig(cmdConfig *Config, fileConfig *ConfigFile) *Config {
 	if result.Tables == "" {
 		result.Tables = fileConfig.Tables
 	}
+	if len(result.Include) == 0 {
+		result.Include = fileConfig.Include
+	}
+	if len(result.Exclude) == 0 {
+		result.Exclude = fileConfig.Exclude
+	}
+	if result.Views == "" {
+		result.Views = fileConfig.Views
+	}
 	if !result.GenerateRouter {
 		result.GenerateRouter = fileConfig.Options.GenerateRouter
 	}
@@ -137,6 +279,9 @@ func MergeConfig(cmdConfig *Config, fileConfig *ConfigFile) *Config {
 	if result.ServiceOutput == "" {
 		result.ServiceOutput = fileConfig.Service.Output
 	}
+	if !result.SkipContainer {
+		result.SkipContainer = fileConfig.Service.SkipContainer
+	}
 	// 导入路径合并
 	if result.ModelImportPath == "" {
 		result.ModelImportPath = fileConfig.Imports.Model
@@ -147,6 +292,90 @@ func MergeConfig(cmdConfig *Config, fileConfig *ConfigFile) *Config {
 	if result.StorageImportPath == "" {
 		result.StorageImportPath = fileConfig.Imports.Storage
 	}
+	if result.SortableColumns == nil {
+		result.SortableColumns = fileConfig.List.SortableColumns
+	}
+	if result.Pagination == "" {
+		result.Pagination = fileConfig.List.Pagination
+	}
+	if result.CursorColumns == nil {
+		result.CursorColumns = fileConfig.List.CursorColumns
+	}
+	if result.SearchColumns == nil {
+		result.SearchColumns = fileConfig.Search.Columns
+	}
+	if len(result.VersionColumns) == 0 {
+		result.VersionColumns = fileConfig.Lock.Columns
+	}
+	if result.TenantColumn == "" {
+		result.TenantColumn = fileConfig.Tenant.Column
+	}
+	if result.TenantContextKey == "" {
+		result.TenantContextKey = fileConfig.Tenant.ContextKey
+	}
+	if result.StatusMode == "" {
+		result.StatusMode = fileConfig.Router.StatusMode
+	}
+	if result.Operations == nil {
+		result.Operations = fileConfig.Router.Operations
+	}
+	if result.PermissionFormat == "" {
+		result.PermissionFormat = fileConfig.Router.PermissionFormat
+	}
+	if !result.GenerateGRPC {
+		result.GenerateGRPC = fileConfig.Options.GenerateGRPC
+	}
+	if result.GRPCOutput == "" {
+		result.GRPCOutput = fileConfig.GRPC.Output
+	}
+	if result.ProtoOutput == "" {
+		result.ProtoOutput = fileConfig.GRPC.ProtoOutput
+	}
+	if result.ProtoPackage == "" {
+		result.ProtoPackage = fileConfig.GRPC.ProtoPackage
+	}
+	if result.ProtoImportPath == "" {
+		result.ProtoImportPath = fileConfig.Imports.Proto
+	}
+	if !result.GenerateGraphQL {
+		result.GenerateGraphQL = fileConfig.Options.GenerateGraphQL
+	}
+	if result.GraphQLOutput == "" {
+		result.GraphQLOutput = fileConfig.GraphQL.Output
+	}
+	if result.GraphQLImportPath == "" {
+		result.GraphQLImportPath = fileConfig.Imports.GraphQL
+	}
+	if !result.GenerateTests {
+		result.GenerateTests = fileConfig.Options.GenerateTests
+	}
+	if result.TSOutput == "" {
+		result.TSOutput = fileConfig.TS.Output
+	}
+	if len(result.Initialisms) == 0 {
+		result.Initialisms = fileConfig.Naming.Initialisms
+	}
+	if len(result.TablePrefixes) == 0 {
+		result.TablePrefixes = fileConfig.Naming.StripPrefixes
+	}
+	if result.TableNaming == nil {
+		result.TableNaming = fileConfig.Naming.Tables
+	}
+	if result.JSONCase == "" {
+		result.JSONCase = fileConfig.Naming.JSONCase
+	}
+	if result.RouteCase == "" {
+		result.RouteCase = fileConfig.Naming.RouteCase
+	}
+	if len(result.ShardPatterns) == 0 {
+		result.ShardPatterns = fileConfig.Sharding.Patterns
+	}
+	if result.ViewKeys == nil {
+		result.ViewKeys = fileConfig.ViewKeys
+	}
+	if result.JSONTypes == nil {
+		result.JSONTypes = fileConfig.JSONTypes
+	}
 
 	return result
 }
@@ -0,0 +1,347 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+)
+
+// EnumInfo 枚举（enum）与集合（set）列生成的 Go 类型，由 resolveNames 计算
+type EnumInfo struct {
+	// TypeName 字段类型名，如 ArticleStatus；集合为元素类型的切片，如 ArticleTags
+	TypeName string
+	// ElemName 取值类型名，枚举与 TypeName 相同，集合为元素类型名，如 ArticleTag
+	ElemName string
+	// Set 是否为集合
+	Set bool
+	// Values 按数据库定义顺序排列的取值
+	Values []EnumValue
+}
+
+// EnumValue 枚举取值及其常量名
+type EnumValue struct {
+	Const string
+	Value string
+}
+
+// parseEnumValues 从 COLUMN_TYPE 解析 enum('a','b') 或 set('a','b') 的取值，其他类型返回 nil。
+// 取值中的单引号以两个单引号或反斜杠转义
+func parseEnumValues(columnType string) []string {
+	lower := strings.ToLower(columnType)
+	var rest string
+	switch {
+	case strings.HasPrefix(lower, "enum("):
+		rest = columnType[len("enum("):]
+	case strings.HasPrefix(lower, "set("):
+		rest = columnType[len("set("):]
+	default:
+		return nil
+	}
+
+	var values []string
+	var value strings.Builder
+	inQuote := false
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case !inQuote && c == '\'':
+			inQuote = true
+			value.Reset()
+		case inQuote && c == '\\' && i+1 < len(rest):
+			i++
+			value.WriteByte(rest[i])
+		case inQuote && c == '\'' && i+1 < len(rest) && rest[i+1] == '\'':
+			i++
+			value.WriteByte('\'')
+		case inQuote && c == '\'':
+			inQuote = false
+			values = append(values, value.String())
+		case inQuote:
+			value.WriteByte(c)
+		}
+	}
+	return values
+}
+
+// isEnumColumn 判断列是否生成枚举类型：enum/set 列且解析到取值，主键列保持字符串
+func isEnumColumn(col ColumnInfo) bool {
+	typ := strings.ToLower(col.Type)
+	return (typ == "enum" || typ == "set") && len(col.EnumValues) > 0 && !col.IsPrimaryKey
+}
+
+// resolveEnums 为表的 enum/set 列分配类型名与常量名，used 为模型包中已占用的名称。
+// 类型名为结构体名加字段名（ArticleStatus），集合的元素类型名取字段名的单数（ArticleTags 的元素为 ArticleTag）
+func (g *Generator) resolveEnums(table *TableInfo, used map[string]string) {
+	for j := range table.Columns {
+		col := &table.Columns[j]
+		if !isEnumColumn(*col) {
+			continue
+		}
+		owner := fmt.Sprintf("表 %s 的列 %s", table.Name, col.Name)
+		enum := &EnumInfo{Set: strings.EqualFold(col.Type, "set")}
+
+		name := table.GoName + col.GoName
+		enum.TypeName = uniqueName(name, used, owner)
+		if enum.TypeName != name {
+			log.Printf("警告: %s 的类型名 %s 已被 %s 占用，改用 %s", owner, name, used[name], enum.TypeName)
+		}
+		enum.ElemName = enum.TypeName
+		if enum.Set {
+			name = table.GoName + inflection.Singular(col.GoName)
+			if name == enum.TypeName {
+				name += "Value"
+			}
+			enum.ElemName = uniqueName(name, used, owner)
+			if enum.ElemName != name {
+				log.Printf("警告: %s 的元素类型名 %s 已被 %s 占用，改用 %s", owner, name, used[name], enum.ElemName)
+			}
+		}
+
+		for i, value := range col.EnumValues {
+			var suffix strings.Builder
+			for _, word := range splitWords(value) {
+				suffix.WriteString(g.formatWord(word))
+			}
+			if suffix.Len() == 0 {
+				suffix.WriteString("Value" + strconv.Itoa(i+1))
+			}
+			name := enum.ElemName + suffix.String()
+			constName := uniqueName(name, used, owner+" 的取值 "+value)
+			if constName != name {
+				log.Printf("警告: %s 的取值 %q 的常量名 %s 已被 %s 占用，改用 %s", owner, value, name, used[name], constName)
+			}
+			enum.Values = append(enum.Values, EnumValue{Const: constName, Value: value})
+		}
+
+		col.Enum = enum
+		col.GoType = enum.TypeName
+		col.GoTag = g.generateGoTag(*col)
+	}
+}
+
+// enumBinding 生成校验枚举取值的 binding 标签（gin 的 oneof 校验），
+// 取值含有无法写入标签的字符（逗号、竖线、引号、反引号、反斜杠）时返回空字符串。
+// JSON 请求中的非法取值由 UnmarshalJSON 在解析时拒绝，该标签用于表单等不经过 UnmarshalJSON 的绑定
+func enumBinding(col ColumnInfo) string {
+	var values []string
+	for _, v := range col.Enum.Values {
+		if v.Value == "" || strings.ContainsAny(v.Value, ",|'\"`\\") {
+			return ""
+		}
+		if strings.ContainsAny(v.Value, " \t") {
+			v.Value = "'" + v.Value + "'"
+		}
+		values = append(values, v.Value)
+	}
+	if col.Enum.Set {
+		return "omitempty,dive,oneof=" + strings.Join(values, " ")
+	}
+	return "omitempty,oneof=" + strings.Join(values, " ")
+}
+
+// baseGoType 获取列的基础 Go 类型：枚举为 string，集合为 []string，其他列与 GoType 相同。
+// 用于类型映射（protobuf、GraphQL、TypeScript）、零值与过滤类别等只关心底层类型的场合
+func baseGoType(col ColumnInfo) string {
+	switch {
+	case col.Enum == nil:
+		return col.GoType
+	case col.Enum.Set:
+		return "[]string"
+	default:
+		return "string"
+	}
+}
+
+// enumTemplateData 准备模型文件中枚举类型的模板数据
+func (g *Generator) enumTemplateData(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		if col.Enum == nil {
+			continue
+		}
+		comment := col.Comment
+		if comment == "" {
+			comment = table.Name + "." + col.Name
+		}
+		var values []map[string]interface{}
+		for _, v := range col.Enum.Values {
+			values = append(values, map[string]interface{}{
+				"Const":   v.Const,
+				"Literal": strconv.Quote(v.Value),
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"TypeName": col.Enum.TypeName,
+			"ElemName": col.Enum.ElemName,
+			"Set":      col.Enum.Set,
+			"Nullable": col.IsNullable,
+			"Comment":  comment,
+			"Values":   values,
+		})
+	}
+	return result
+}
+
+// enumTemplate 枚举与集合类型：常量、取值校验、JSON 解析与数据库读写
+const enumTemplate = `{{define "enums"}}
+{{- range .Enums}}
+{{- $elem := .ElemName}}
+
+// {{.ElemName}} {{.Comment}}{{if .Set}}的取值{{end}}
+type {{.ElemName}} string
+
+const (
+	{{- range .Values}}
+	{{.Const}} {{$elem}} = {{.Literal}}
+	{{- end}}
+)
+
+// {{.ElemName}}Values {{.Comment}}的全部取值，按数据库定义的顺序排列
+var {{.ElemName}}Values = []{{.ElemName}}{
+	{{- range .Values}}
+	{{.Const}},
+	{{- end}}
+}
+
+// Valid 判断是否为合法取值
+func (e {{.ElemName}}) Valid() bool {
+	for _, v := range {{.ElemName}}Values {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String 返回取值字符串
+func (e {{.ElemName}}) String() string {
+	return string(e)
+}
+
+// UnmarshalJSON 解析 JSON 字符串并校验取值，空字符串与 null 视为未设置
+func (e *{{.ElemName}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if v := {{.ElemName}}(s); v != "" && !v.Valid() {
+		return &InvalidValueError{Field: "{{.Comment}}", Value: s}
+	}
+	*e = {{.ElemName}}(s)
+	return nil
+}
+{{- if .Set}}
+
+// {{.TypeName}} {{.Comment}}，数据库中以逗号分隔存储
+type {{.TypeName}} []{{.ElemName}}
+
+// {{.TypeName}}FromStrings 将字符串切片转换为{{.Comment}}，nil 保持为 nil
+func {{.TypeName}}FromStrings(values []string) {{.TypeName}} {
+	if values == nil {
+		return nil
+	}
+	result := make({{.TypeName}}, len(values))
+	for i, v := range values {
+		result[i] = {{.ElemName}}(v)
+	}
+	return result
+}
+
+// Contains 判断是否包含取值 v
+func (s {{.TypeName}}) Contains(v {{.ElemName}}) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings 返回取值字符串切片
+func (s {{.TypeName}}) Strings() []string {
+	if s == nil {
+		return nil
+	}
+	result := make([]string, len(s))
+	for i, v := range s {
+		result[i] = string(v)
+	}
+	return result
+}
+
+// Scan 实现 sql.Scanner，按逗号拆分数据库中的值
+func (s *{{.TypeName}}) Scan(value interface{}) error {
+	var str string
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return fmt.Errorf("无法将 %T 转换为{{.Comment}}", value)
+	}
+	if str == "" {
+		*s = {{.TypeName}}{}
+		return nil
+	}
+	*s = {{.TypeName}}FromStrings(strings.Split(str, ","))
+	return nil
+}
+
+// Value 实现 driver.Valuer，校验每个取值后以逗号连接{{if .Nullable}}，nil 写入 NULL{{end}}
+func (s {{.TypeName}}) Value() (driver.Value, error) {
+	{{- if .Nullable}}
+	if s == nil {
+		return nil, nil
+	}
+	{{- end}}
+	for _, v := range s {
+		if !v.Valid() {
+			return nil, &InvalidValueError{Field: "{{.Comment}}", Value: string(v)}
+		}
+	}
+	return strings.Join(s.Strings(), ","), nil
+}
+
+// GormDataType 集合在 GORM 中按字符串类型处理
+func ({{.TypeName}}) GormDataType() string {
+	return "string"
+}
+{{- else}}
+
+// Scan 实现 sql.Scanner
+func (e *{{.ElemName}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = ""
+	case []byte:
+		*e = {{.ElemName}}(v)
+	case string:
+		*e = {{.ElemName}}(v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为{{.Comment}}", value)
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer，拒绝写入非法取值{{if .Nullable}}，空字符串写入 NULL{{else}}，包括未设置的空字符串{{end}}
+func (e {{.ElemName}}) Value() (driver.Value, error) {
+	{{- if .Nullable}}
+	if e == "" {
+		return nil, nil
+	}
+	{{- end}}
+	if !e.Valid() {
+		return nil, &InvalidValueError{Field: "{{.Comment}}", Value: string(e)}
+	}
+	return string(e), nil
+}
+{{- end}}
+{{- end}}
+{{- end}}`
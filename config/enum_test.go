@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEnumValues(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       []string
+	}{
+		{"enum('draft','published')", []string{"draft", "published"}},
+		{"SET('a','b c')", []string{"a", "b c"}},
+		{"enum('it''s','a\\'b','x,y','')", []string{"it's", "a'b", "x,y", ""}},
+		{"enum('开心','难过')", []string{"开心", "难过"}},
+		{"varchar(255)", nil},
+	}
+	for _, tt := range tests {
+		if got := parseEnumValues(tt.columnType); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseEnumValues(%q) = %q, 期望 %q", tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestResolveEnums(t *testing.T) {
+	g := NewGenerator(&Config{})
+	table := TableInfo{Name: "articles", GoName: "Article", Columns: []ColumnInfo{
+		testColumn(g, "status", "enum", false, false, false, "状态"),
+		testColumn(g, "tags", "set", true, false, false, "标签"),
+		testColumn(g, "code", "enum", false, true, false, "编码"),
+		testColumn(g, "mood", "enum", true, false, false, ""),
+	}}
+	table.Columns[0].EnumValues = []string{"draft", "in review"}
+	table.Columns[1].EnumValues = []string{"hot"}
+	table.Columns[2].EnumValues = []string{"a"}
+	table.Columns[3].EnumValues = []string{"", "1st"}
+	for j := range table.Columns {
+		table.Columns[j].GoName = g.toCamelCase(table.Columns[j].Name)
+	}
+	used := map[string]string{"ArticleStatus": "表 article_status"}
+	g.resolveEnums(&table, used)
+
+	status := table.Columns[0].Enum
+	if status == nil || status.TypeName != "ArticleStatus2" || status.Set {
+		t.Fatalf("status 的枚举信息 = %+v", status)
+	}
+	if got := []string{status.Values[0].Const, status.Values[1].Const}; !reflect.DeepEqual(got, []string{"ArticleStatus2Draft", "ArticleStatus2InReview"}) {
+		t.Errorf("status 的常量名 = %v", got)
+	}
+	if table.Columns[0].GoType != "ArticleStatus2" {
+		t.Errorf("status 的 GoType = %s", table.Columns[0].GoType)
+	}
+
+	tags := table.Columns[1].Enum
+	if tags == nil || !tags.Set || tags.TypeName != "ArticleTags" || tags.ElemName != "ArticleTag" {
+		t.Fatalf("tags 的集合信息 = %+v", tags)
+	}
+	if tags.Values[0].Const != "ArticleTagHot" {
+		t.Errorf("tags 的常量名 = %s", tags.Values[0].Const)
+	}
+
+	if table.Columns[2].Enum != nil {
+		t.Error("主键列不应生成枚举类型")
+	}
+
+	mood := table.Columns[3].Enum
+	if got := []string{mood.Values[0].Const, mood.Values[1].Const}; !reflect.DeepEqual(got, []string{"ArticleMoodValue1", "ArticleMood1st"}) {
+		t.Errorf("mood 的常量名 = %v", got)
+	}
+}
+
+func TestEnumBinding(t *testing.T) {
+	tests := []struct {
+		values []string
+		set    bool
+		want   string
+	}{
+		{[]string{"draft", "in review"}, false, "omitempty,oneof=draft 'in review'"},
+		{[]string{"hot", "new"}, true, "omitempty,dive,oneof=hot new"},
+		{[]string{"a,b"}, false, ""},
+		{[]string{""}, false, ""},
+	}
+	for _, tt := range tests {
+		col := ColumnInfo{Enum: &EnumInfo{Set: tt.set}}
+		for _, v := range tt.values {
+			col.Enum.Values = append(col.Enum.Values, EnumValue{Value: v})
+		}
+		if got := enumBinding(col); got != tt.want {
+			t.Errorf("enumBinding(%q) = %q, 期望 %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// regexPrefix 表名模式的正则前缀，如 re:^user_\d+$；其余模式按通配符匹配，如 user_*、*_bak
+const regexPrefix = "re:"
+
+// tablePattern 编译后的表名匹配模式
+type tablePattern struct {
+	glob  string
+	regex *regexp.Regexp
+}
+
+// match 判断表名是否匹配模式，正则需匹配表名的一部分，通配符需匹配整个表名
+func (p tablePattern) match(name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(name)
+	}
+	ok, _ := path.Match(p.glob, name)
+	return ok
+}
+
+// tableFilter 按包含、排除模式筛选表
+type tableFilter struct {
+	include []tablePattern
+	exclude []tablePattern
+}
+
+// newTableFilter 编译 Include、Exclude 模式，模式无效时返回错误
+func newTableFilter(include, exclude []string) (*tableFilter, error) {
+	f := &tableFilter{}
+	var err error
+	if f.include, err = compileTablePatterns(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = compileTablePatterns(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// compileTablePatterns 编译表名模式，忽略空白模式
+func compileTablePatterns(patterns []string) ([]tablePattern, error) {
+	var result []tablePattern
+	for _, raw := range patterns {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		var p tablePattern
+		if expr, ok := strings.CutPrefix(raw, regexPrefix); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("无效的表名正则 %s: %w", raw, err)
+			}
+			p.regex = re
+		} else {
+			if _, err := path.Match(raw, ""); err != nil {
+				return nil, fmt.Errorf("无效的表名模式 %s: %w", raw, err)
+			}
+			p.glob = raw
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// match 判断是否生成该表：配置了包含模式时须匹配其一，且不匹配任何排除模式
+func (f *tableFilter) match(name string) bool {
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	return !matchAny(f.exclude, name)
+}
+
+// matchAny 判断表名是否匹配任一模式
+func matchAny(patterns []tablePattern, name string) bool {
+	for _, p := range patterns {
+		if p.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitList 拆分逗号分隔的列表（如命令行参数 -tables、-include），去除空白与空项
+func SplitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableFilter(t *testing.T) {
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             []string
+	}{
+		{"全部", nil, nil, []string{"users", "user_roles", "user_roles_bak", "tmp_import", "articles", "logs_2024"}},
+		{"通配符包含", []string{"user*"}, nil, []string{"users", "user_roles", "user_roles_bak"}},
+		{"包含与排除", []string{"user_*"}, []string{"*_bak", "tmp_*"}, []string{"user_roles"}},
+		{"只排除", nil, []string{"*_bak", " tmp_* ", ""}, []string{"users", "user_roles", "articles", "logs_2024"}},
+		{"正则", []string{`re:_\d+$`, "articles"}, nil, []string{"articles", "logs_2024"}},
+		{"正则排除", nil, []string{`re:^(tmp|user)_`}, []string{"users", "articles", "logs_2024"}},
+	}
+	names := []string{"users", "user_roles", "user_roles_bak", "tmp_import", "articles", "logs_2024"}
+	for _, tt := range tests {
+		f, err := newTableFilter(tt.include, tt.exclude)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for _, name := range names {
+			if f.match(name) {
+				got = append(got, name)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: 匹配结果 = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTableFilterInvalid(t *testing.T) {
+	for _, pattern := range []string{"user_[", "re:user_("} {
+		if _, err := newTableFilter([]string{pattern}, nil); err == nil {
+			t.Errorf("模式 %q 应返回错误", pattern)
+		}
+		if _, err := newTableFilter(nil, []string{pattern}); err == nil {
+			t.Errorf("排除模式 %q 应返回错误", pattern)
+		}
+	}
+}
+
+func TestTablesQuery(t *testing.T) {
+	tests := []struct {
+		tables, views string
+		where         []string
+		args          []interface{}
+	}{
+		{"", "", []string{"TABLE_SCHEMA = ?", "TABLE_TYPE IN ('BASE TABLE', 'VIEW')"}, []interface{}{"app"}},
+		{"users, articles,", "", []string{"TABLE_SCHEMA = ?", "TABLE_NAME IN (?,?)", "TABLE_TYPE IN ('BASE TABLE', 'VIEW')"}, []interface{}{"app", "users", "articles"}},
+		{"", ViewsSkip, []string{"TABLE_SCHEMA = ?", "TABLE_TYPE = 'BASE TABLE'"}, []interface{}{"app"}},
+		{"users", ViewsOnly, []string{"TABLE_SCHEMA = ?", "TABLE_NAME IN (?)", "TABLE_TYPE = 'VIEW'"}, []interface{}{"app", "users"}},
+	}
+	for _, tt := range tests {
+		g := NewGenerator(&Config{Database: "app", Tables: tt.tables, Views: tt.views})
+		query, args := g.tablesQuery()
+
+		// WHERE 与 ORDER BY 之间的条件以 AND 连接
+		where := query[strings.Index(query, "WHERE")+len("WHERE") : strings.Index(query, "ORDER BY")]
+		var conditions []string
+		for _, cond := range strings.Split(where, "AND") {
+			conditions = append(conditions, strings.TrimSpace(cond))
+		}
+		if !reflect.DeepEqual(conditions, tt.where) {
+			t.Errorf("tables=%q views=%q: 查询条件 = %q, 期望 %q", tt.tables, tt.views, conditions, tt.where)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("tables=%q views=%q: 参数 = %v, 期望 %v", tt.tables, tt.views, args, tt.args)
+		}
+	}
+}
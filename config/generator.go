@@ -1,12 +1,16 @@
 package generator
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
+	"go/format"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -38,14 +42,157 @@ type Config struct {
 	// StorageImportPath 指向存储层包的导入路径根（用于在 Service 中引用存储实现，如 DAO/Repository）。
 	// 例如: "github.com/your/app/internal/storage"
 	StorageImportPath string
+	// SortableColumns 按表名配置额外允许排序的字段（索引字段默认可排序）
+	SortableColumns map[string][]string
+	// Pagination 列表分页模式: offset（默认）或 cursor
+	Pagination string
+	// CursorColumns 按表名配置游标分页使用的索引字段，未配置时使用主键
+	CursorColumns map[string]string
+	// SearchColumns 按表名配置搜索字段，未配置时优先使用全文索引字段
+	SearchColumns map[string][]string
+	// VersionColumns 乐观锁版本字段名，表中存在其中任一字段时启用乐观锁
+	VersionColumns []string
+	// TenantColumn 多租户字段名，包含该字段的表的所有查询按 context 中的租户 ID 限定
+	TenantColumn string
+	// TenantContextKey Router 从 gin 上下文读取租户 ID 的键，默认与 TenantColumn 相同
+	TenantContextKey string
+	// StatusMode Router 错误响应的 HTTP 状态码策略: envelope（默认）或 http
+	StatusMode string
+	// Operations 按表名配置生成的路由操作，未配置的表生成全部操作
+	Operations map[string][]string
+	// PermissionFormat 路由权限名格式，{table} 替换为表名，{op} 替换为操作名，默认 {table}:{op}
+	PermissionFormat string
+	// GenerateGRPC 是否生成 .proto 文件与 gRPC 服务端实现
+	GenerateGRPC bool
+	// GRPCOutput gRPC 服务端代码输出目录
+	GRPCOutput string
+	// ProtoOutput .proto 文件输出目录
+	ProtoOutput string
+	// ProtoPackage .proto 文件的 package
+	ProtoPackage string
+	// ProtoImportPath protoc 生成的 Go 代码导入路径，同时作为 .proto 的 go_package
+	// 例如: "github.com/your/app/internal/pb"
+	ProtoImportPath string
+	// GenerateGraphQL 是否生成 GraphQL schema 与 gqlgen 解析器
+	GenerateGraphQL bool
+	// GraphQLOutput GraphQL 代码输出目录
+	GraphQLOutput string
+	// GraphQLImportPath GraphQL 解析器包导入路径，写入 gqlgen.yml 的模型绑定
+	// 例如: "github.com/your/app/internal/graph"
+	GraphQLImportPath string
+	// GenerateTests 是否为生成的 Service 与 Router 生成基于 SQLite 的单元测试
+	GenerateTests bool
+	// SkipContainer 不生成 Service 容器 services.go（Repos、NewRepos 与 Transaction），默认生成；
+	// gRPC 与 GraphQL 通过 Repos 调用 Service，开启二者时忽略该选项
+	SkipContainer bool
+	// TSOutput TypeScript 类型与接口客户端输出目录，为空时不生成
+	TSOutput string
+	// Initialisms 额外的缩写词，生成 Go 名称时与内置的 golint 缩写词一样整体大写，如 SKU
+	Initialisms []string
+	// TablePrefixes 推导名称前去除的表名前缀，如 t_、tb_，按顺序匹配第一个
+	TablePrefixes []string
+	// TableNaming 按表名覆盖结构体名、文件名与路由路径
+	TableNaming map[string]TableNaming
+	// JSONCase 模型 JSON 标签的命名风格: snake（默认）、camel 或 original
+	JSONCase string
+	// RouteCase 默认路由路径的命名风格: snake（默认，如 order_items）或 kebab（如 order-items）
+	RouteCase string
+	// Include 表名包含模式，支持通配符（user_*）与 re: 前缀的正则，为空时包含全部表
+	Include []string
+	// Exclude 表名排除模式，格式同 Include
+	Exclude []string
+	// Views 视图处理方式: include（默认，与表一起生成）、skip（跳过视图）或 only（只生成视图）
+	Views string
+	// ShardPatterns 分表规则，匹配的表按正则捕获的逻辑表名（命名分组 name 或第一个分组）合并为一个模型，
+	// 如 ^(order)_\d+$ 将 order_00 ~ order_63 合并为 Order
+	ShardPatterns []string
+	// ViewKeys 按视图名配置主键列，未配置的视图使用 id 列（存在时）作为主键
+	ViewKeys map[string][]string
+	// JSONTypes 按 表名.列名 将 JSON 列映射为自定义 Go 类型，如 example.com/app/types.ArticleMeta，
+	// 未配置的 JSON 列使用 datatypes.JSON
+	JSONTypes map[string]string
 }
 
+// TableNaming 单表的命名覆盖，为空的字段使用默认规则
+type TableNaming struct {
+	// Struct 模型结构体名，如 Member
+	Struct string `yaml:"struct"`
+	// File 生成文件名（不含后缀），如 member
+	File string `yaml:"file"`
+	// Route 路由路径（不含前导 /），如 members
+	Route string `yaml:"route"`
+}
+
+// 分页模式
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+// 错误响应状态码策略
+const (
+	// StatusModeEnvelope 始终返回 HTTP 200，错误码只放在响应体的 code 中
+	StatusModeEnvelope = "envelope"
+	// StatusModeHTTP 错误响应使用与 code 相同的 HTTP 状态码
+	StatusModeHTTP = "http"
+)
+
+// JSON 标签命名风格
+const (
+	// JSONCaseSnake 蛇形命名，如 user_id
+	JSONCaseSnake = "snake"
+	// JSONCaseCamel 小驼峰命名，如 userId
+	JSONCaseCamel = "camel"
+	// JSONCaseOriginal 与列名相同
+	JSONCaseOriginal = "original"
+)
+
+// 视图处理方式
+const (
+	ViewsInclude = "include"
+	ViewsSkip    = "skip"
+	ViewsOnly    = "only"
+)
+
+// 路由路径命名风格
+const (
+	RouteCaseSnake = "snake"
+	RouteCaseKebab = "kebab"
+)
+
 // TableInfo 表信息
 type TableInfo struct {
-	Name        string
+	Name string
+	// GoName 模型结构体名，由 resolveNames 计算
+	GoName string
+	// FileName 生成文件名（不含后缀），由 resolveNames 计算
+	FileName string
+	// RoutePath 路由路径（不含前导 /），由 resolveNames 计算
+	RoutePath string
+	// IsView 是否为视图
+	IsView bool
+	// Shards 合并为该逻辑表的分表名（按自然顺序排序），非分表为空
+	Shards      []string
 	Comment     string
 	Columns     []ColumnInfo
 	PrimaryKeys []string
+	Indexes     []IndexInfo
+	ForeignKeys []ForeignKeyInfo
+}
+
+// ForeignKeyInfo 外键信息（单列）
+type ForeignKeyInfo struct {
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// IndexInfo 索引信息
+type IndexInfo struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Type    string
 }
 
 // ColumnInfo 列信息
@@ -59,12 +206,28 @@ type ColumnInfo struct {
 	DefaultValue string
 	GoType       string
 	GoTag        string
+	// GoName 模型字段名，由 resolveNames 计算
+	GoName string
+	// JSONName JSON 字段名，由 resolveNames 计算
+	JSONName string
+	// ProtoName protobuf 字段名，由 resolveNames 计算
+	ProtoName string
+	// GraphQLName GraphQL 字段名，由 resolveNames 计算
+	GraphQLName string
+	// EnumValues enum/set 列的取值，从 COLUMN_TYPE 解析
+	EnumValues []string
+	// Enum enum/set 列生成的 Go 类型，由 resolveNames 计算，其他列为 nil
+	Enum *EnumInfo
+	// JSON JSON 列的映射信息，由 resolveNames 计算，其他列为 nil
+	JSON *JSONInfo
 }
 
 // Generator 代码生成器
 type Generator struct {
 	config *Config
 	db     *sql.DB
+	// initialisms 生成 Go 名称时整体大写的缩写词
+	initialisms map[string]bool
 }
 
 // NewGenerator 创建生成器
@@ -76,9 +239,78 @@ func NewGenerator(config *Config) *Generator {
 	if config.ServiceOutput == "" {
 		config.ServiceOutput = filepath.Join(config.Output, "../services")
 	}
+	if config.TenantColumn != "" && config.TenantContextKey == "" {
+		config.TenantContextKey = config.TenantColumn
+	}
+	switch config.Pagination {
+	case "":
+		config.Pagination = PaginationOffset
+	case PaginationOffset, PaginationCursor:
+	default:
+		log.Printf("警告: 未知的分页模式 %s，使用 offset", config.Pagination)
+		config.Pagination = PaginationOffset
+	}
+	if config.GRPCOutput == "" {
+		config.GRPCOutput = filepath.Join(config.Output, "../grpcserver")
+	}
+	if config.ProtoOutput == "" {
+		config.ProtoOutput = filepath.Join(config.Output, "../../api/proto")
+	}
+	if config.ProtoPackage == "" {
+		config.ProtoPackage = "api"
+	}
+	if config.ProtoImportPath == "" && config.ModelImportPath != "" {
+		config.ProtoImportPath = path.Join(path.Dir(config.ModelImportPath), "pb")
+	}
+	if config.GraphQLOutput == "" {
+		config.GraphQLOutput = filepath.Join(config.Output, "../graph")
+	}
+	if config.GraphQLImportPath == "" && config.ModelImportPath != "" {
+		config.GraphQLImportPath = path.Join(path.Dir(config.ModelImportPath), "graph")
+	}
+	if config.SkipContainer && (config.GenerateGRPC || config.GenerateGraphQL) {
+		log.Printf("警告: gRPC 与 GraphQL 通过 Service 容器调用 Service，仍生成 services.go")
+		config.SkipContainer = false
+	}
+	if config.PermissionFormat == "" {
+		config.PermissionFormat = "{table}:{op}"
+	}
+	switch config.StatusMode {
+	case "":
+		config.StatusMode = StatusModeEnvelope
+	case StatusModeEnvelope, StatusModeHTTP:
+	default:
+		log.Printf("警告: 未知的状态码策略 %s，使用 envelope", config.StatusMode)
+		config.StatusMode = StatusModeEnvelope
+	}
+	switch config.JSONCase {
+	case "":
+		config.JSONCase = JSONCaseSnake
+	case JSONCaseSnake, JSONCaseCamel, JSONCaseOriginal:
+	default:
+		log.Printf("警告: 未知的 JSON 命名风格 %s，使用 snake", config.JSONCase)
+		config.JSONCase = JSONCaseSnake
+	}
+	switch config.RouteCase {
+	case "":
+		config.RouteCase = RouteCaseSnake
+	case RouteCaseSnake, RouteCaseKebab:
+	default:
+		log.Printf("警告: 未知的路由命名风格 %s，使用 snake", config.RouteCase)
+		config.RouteCase = RouteCaseSnake
+	}
+	switch config.Views {
+	case "":
+		config.Views = ViewsInclude
+	case ViewsInclude, ViewsSkip, ViewsOnly:
+	default:
+		log.Printf("警告: 未知的视图处理方式 %s，使用 include", config.Views)
+		config.Views = ViewsInclude
+	}
 
 	return &Generator{
-		config: config,
+		config:      config,
+		initialisms: buildInitialisms(config.Initialisms),
 	}
 }
 
@@ -96,6 +328,20 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("获取表信息失败: %w", err)
 	}
 
+	return g.generate(tables)
+}
+
+// generate 根据表信息生成全部代码，不依赖数据库连接
+func (g *Generator) generate(tables []TableInfo) error {
+	tables, err := g.collapseShards(g.resolveViewKeys(tables))
+	if err != nil {
+		return err
+	}
+	tables = g.resolveNames(tables)
+	if err := g.checkTenantColumns(tables); err != nil {
+		return err
+	}
+
 	// 创建输出目录
 	if err := os.MkdirAll(g.config.Output, 0755); err != nil {
 		return fmt.Errorf("创建输出目录失败: %w", err)
@@ -129,6 +375,27 @@ func (g *Generator) Generate() error {
 		}
 	}
 
+	// 生成 gRPC 代码
+	if g.config.GenerateGRPC {
+		if err := g.generateGRPC(tables); err != nil {
+			return fmt.Errorf("生成 gRPC 代码失败: %w", err)
+		}
+	}
+
+	// 生成 GraphQL 代码
+	if g.config.GenerateGraphQL {
+		if err := g.generateGraphQL(tables); err != nil {
+			return fmt.Errorf("生成 GraphQL 代码失败: %w", err)
+		}
+	}
+
+	// 生成 TypeScript 代码
+	if g.config.TSOutput != "" {
+		if err := g.generateTypeScript(tables); err != nil {
+			return fmt.Errorf("生成 TypeScript 代码失败: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -159,37 +426,12 @@ func (g *Generator) connectDB() error {
 func (g *Generator) getTables() ([]TableInfo, error) {
 	var tables []TableInfo
 
-	// 构建查询条件
-	whereClause := ""
-	if g.config.Tables != "" {
-		tableNames := strings.Split(g.config.Tables, ",")
-		placeholders := make([]string, len(tableNames))
-		for i := range tableNames {
-			placeholders[i] = "?"
-		}
-		whereClause = fmt.Sprintf("WHERE TABLE_NAME IN (%s)", strings.Join(placeholders, ","))
-	}
-
-	query := fmt.Sprintf(`
-		SELECT 
-			TABLE_NAME,
-			TABLE_COMMENT
-		FROM 
-			INFORMATION_SCHEMA.TABLES 
-		%s
-		AND TABLE_SCHEMA = ?
-		ORDER BY TABLE_NAME
-	`, whereClause)
-
-	var args []interface{}
-	if g.config.Tables != "" {
-		tableNames := strings.Split(g.config.Tables, ",")
-		for _, name := range tableNames {
-			args = append(args, strings.TrimSpace(name))
-		}
+	filter, err := newTableFilter(g.config.Include, g.config.Exclude)
+	if err != nil {
+		return nil, err
 	}
-	args = append(args, g.config.Database)
 
+	query, args := g.tablesQuery()
 	rows, err := g.db.Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -198,9 +440,18 @@ func (g *Generator) getTables() ([]TableInfo, error) {
 
 	for rows.Next() {
 		var table TableInfo
-		if err := rows.Scan(&table.Name, &table.Comment); err != nil {
+		var tableType string
+		if err := rows.Scan(&table.Name, &tableType, &table.Comment); err != nil {
 			return nil, err
 		}
+		if !filter.match(table.Name) {
+			continue
+		}
+		table.IsView = tableType == "VIEW"
+		if table.IsView && table.Comment == "VIEW" {
+			// MySQL 视图的 TABLE_COMMENT 固定为 VIEW
+			table.Comment = ""
+		}
 
 		// 获取列信息
 		columns, err := g.getColumns(table.Name)
@@ -216,18 +467,70 @@ func (g *Generator) getTables() ([]TableInfo, error) {
 		}
 		table.PrimaryKeys = primaryKeys
 
+		// 获取索引信息
+		indexes, err := g.getIndexes(table.Name)
+		if err != nil {
+			return nil, err
+		}
+		table.Indexes = indexes
+
+		// 获取外键信息
+		foreignKeys, err := g.getForeignKeys(table.Name)
+		if err != nil {
+			return nil, err
+		}
+		table.ForeignKeys = foreignKeys
+
 		tables = append(tables, table)
 	}
 
 	return tables, nil
 }
 
+// tablesQuery 构造查询表信息的 SQL 与参数：限定当前数据库，按 Tables 列表与视图处理方式过滤；
+// Include、Exclude 模式在读取结果时匹配
+func (g *Generator) tablesQuery() (string, []interface{}) {
+	conditions := []string{"TABLE_SCHEMA = ?"}
+	args := []interface{}{g.config.Database}
+
+	if names := SplitList(g.config.Tables); len(names) > 0 {
+		placeholders := make([]string, len(names))
+		for i, name := range names {
+			placeholders[i] = "?"
+			args = append(args, name)
+		}
+		conditions = append(conditions, fmt.Sprintf("TABLE_NAME IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	switch g.config.Views {
+	case ViewsSkip:
+		conditions = append(conditions, "TABLE_TYPE = 'BASE TABLE'")
+	case ViewsOnly:
+		conditions = append(conditions, "TABLE_TYPE = 'VIEW'")
+	default:
+		conditions = append(conditions, "TABLE_TYPE IN ('BASE TABLE', 'VIEW')")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT 
+			TABLE_NAME,
+			TABLE_TYPE,
+			TABLE_COMMENT
+		FROM 
+			INFORMATION_SCHEMA.TABLES 
+		WHERE %s
+		ORDER BY TABLE_NAME
+	`, strings.Join(conditions, "\n\t\t\tAND "))
+	return query, args
+}
+
 // getColumns 获取列信息
 func (g *Generator) getColumns(tableName string) ([]ColumnInfo, error) {
 	query := `
 		SELECT 
 			COLUMN_NAME,
 			DATA_TYPE,
+			COLUMN_TYPE,
 			COLUMN_COMMENT,
 			IS_NULLABLE,
 			COLUMN_KEY,
@@ -250,11 +553,12 @@ func (g *Generator) getColumns(tableName string) ([]ColumnInfo, error) {
 	var columns []ColumnInfo
 	for rows.Next() {
 		var col ColumnInfo
-		var dataType, isNullable, columnKey, extra, defaultValue sql.NullString
+		var dataType, columnType, isNullable, columnKey, extra, defaultValue sql.NullString
 
 		if err := rows.Scan(
 			&col.Name,
 			&dataType,
+			&columnType,
 			&col.Comment,
 			&isNullable,
 			&columnKey,
@@ -269,6 +573,7 @@ func (g *Generator) getColumns(tableName string) ([]ColumnInfo, error) {
 		col.IsPrimaryKey = columnKey.String == "PRI"
 		col.IsAutoIncr = strings.Contains(extra.String, "auto_increment")
 		col.DefaultValue = defaultValue.String
+		col.EnumValues = parseEnumValues(columnType.String)
 
 		// 转换为 Go 类型
 		col.GoType = g.convertToGoType(col.Type, col.IsNullable)
@@ -311,6 +616,100 @@ func (g *Generator) getPrimaryKeys(tableName string) ([]string, error) {
 	return primaryKeys, nil
 }
 
+// getIndexes 获取索引信息
+func (g *Generator) getIndexes(tableName string) ([]IndexInfo, error) {
+	query := `
+		SELECT 
+			INDEX_NAME,
+			NON_UNIQUE,
+			COLUMN_NAME,
+			INDEX_TYPE
+		FROM 
+			INFORMATION_SCHEMA.STATISTICS 
+		WHERE 
+			TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY 
+			INDEX_NAME, SEQ_IN_INDEX
+	`
+
+	rows, err := g.db.Query(query, g.config.Database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var name, columnName, indexType string
+		var nonUnique int
+		if err := rows.Scan(&name, &nonUnique, &columnName, &indexType); err != nil {
+			return nil, err
+		}
+
+		// 同一索引的多列按顺序追加
+		if n := len(indexes); n > 0 && indexes[n-1].Name == name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, columnName)
+			continue
+		}
+		indexes = append(indexes, IndexInfo{
+			Name:    name,
+			Columns: []string{columnName},
+			Unique:  nonUnique == 0,
+			Type:    indexType,
+		})
+	}
+
+	return indexes, nil
+}
+
+// getForeignKeys 获取外键信息，多列外键被忽略
+func (g *Generator) getForeignKeys(tableName string) ([]ForeignKeyInfo, error) {
+	query := `
+		SELECT 
+			CONSTRAINT_NAME,
+			COLUMN_NAME,
+			REFERENCED_TABLE_NAME,
+			REFERENCED_COLUMN_NAME
+		FROM 
+			INFORMATION_SCHEMA.KEY_COLUMN_USAGE 
+		WHERE 
+			TABLE_SCHEMA = ? AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY 
+			CONSTRAINT_NAME, ORDINAL_POSITION
+	`
+
+	rows, err := g.db.Query(query, g.config.Database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKeyInfo
+	counts := make(map[string]int)
+	var names []string
+	for rows.Next() {
+		var name string
+		var fk ForeignKeyInfo
+		if err := rows.Scan(&name, &fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		counts[name]++
+		names = append(names, name)
+		foreignKeys = append(foreignKeys, fk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []ForeignKeyInfo
+	for i, fk := range foreignKeys {
+		if counts[names[i]] == 1 {
+			result = append(result, fk)
+		}
+	}
+	return result, nil
+}
+
 // convertToGoType 转换为 Go 类型
 func (g *Generator) convertToGoType(dbType string, isNullable bool) string {
 	dbType = strings.ToLower(dbType)
@@ -337,12 +736,14 @@ func (g *Generator) convertToGoType(dbType string, isNullable bool) string {
 		goType = "[]byte"
 	case strings.Contains(dbType, "bool"):
 		goType = "bool"
+	case dbType == "json":
+		goType = "datatypes.JSON"
 	default:
 		goType = "string"
 	}
 
-	// 如果是可空字段，使用指针类型
-	if isNullable && goType != "string" && goType != "[]byte" {
+	// 如果是可空字段，使用指针类型；datatypes.JSON 基于 []byte，nil 即为 NULL
+	if isNullable && goType != "string" && goType != "[]byte" && goType != "datatypes.JSON" {
 		goType = "*" + goType
 	}
 
@@ -363,6 +764,9 @@ func (g *Generator) generateGoTag(col ColumnInfo) string {
 
 	if col.IsAutoIncr {
 		gormTags = append(gormTags, "autoIncrement")
+	} else if col.IsPrimaryKey && (col.GoType == "int" || col.GoType == "int64") {
+		// GORM 默认将整型 id 主键视为自增，联合主键等非自增主键需显式关闭
+		gormTags = append(gormTags, "autoIncrement:false")
 	}
 
 	if !col.IsNullable {
@@ -376,6 +780,11 @@ func (g *Generator) generateGoTag(col ColumnInfo) string {
 		}
 	}
 
+	// 自定义类型的 JSON 列由 GORM 序列化为 JSON
+	if col.JSON != nil && col.JSON.Custom {
+		gormTags = append(gormTags, "serializer:json")
+	}
+
 	if col.Comment != "" {
 		gormTags = append(gormTags, fmt.Sprintf("comment:%s", col.Comment))
 	}
@@ -383,12 +792,34 @@ func (g *Generator) generateGoTag(col ColumnInfo) string {
 	tags = append(tags, fmt.Sprintf("gorm:\"%s\"", strings.Join(gormTags, ";")))
 
 	// JSON 标签
-	jsonName := g.toSnakeCase(col.Name)
-	tags = append(tags, fmt.Sprintf("json:\"%s\"", jsonName))
+	tags = append(tags, fmt.Sprintf("json:\"%s\"", g.jsonName(col)))
+
+	// 枚举取值校验
+	if col.Enum != nil {
+		if binding := enumBinding(col); binding != "" {
+			tags = append(tags, fmt.Sprintf("binding:\"%s\"", binding))
+		}
+	}
 
 	return strings.Join(tags, " ")
 }
 
+// writeGoFile 执行模板并将结果经 gofmt 格式化后写入文件；格式化失败时写入原始内容并返回错误，便于定位模板问题
+func writeGoFile(filePath string, t *template.Template, data interface{}) error {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		if writeErr := os.WriteFile(filePath, buf.Bytes(), 0644); writeErr != nil {
+			return writeErr
+		}
+		return fmt.Errorf("格式化 %s 失败: %w", filePath, err)
+	}
+	return os.WriteFile(filePath, src, 0644)
+}
+
 // extractSize 提取字段大小
 func (g *Generator) extractSize(dbType string) int {
 	re := regexp.MustCompile(`\((\d+)\)`)
@@ -401,33 +832,35 @@ func (g *Generator) extractSize(dbType string) int {
 	return 0
 }
 
-// toSnakeCase 转换为蛇形命名
-func (g *Generator) toSnakeCase(s string) string {
-	var result string
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result += "_"
-		}
-		result += string(r)
-	}
-	return strings.ToLower(result)
-}
-
 // generateBaseModel 生成基础模型
 func (g *Generator) generateBaseModel() error {
 	tmpl := `package {{.Package}}
 
 import (
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 // BaseModel 基础模型，包含所有模型的公共字段
 type BaseModel struct {
-	ID        uint           ` + "`gorm:\"primarykey\" json:\"id\"`" + `
-	CreatedAt time.Time      ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time      ` + "`json:\"updated_at\"`" + `
+	ID        uint           ` + "`gorm:\"primarykey\" json:\"{{.ID}}\"`" + `
+	CreatedAt time.Time      ` + "`json:\"{{.CreatedAt}}\"`" + `
+	UpdatedAt time.Time      ` + "`json:\"{{.UpdatedAt}}\"`" + `
 	DeletedAt gorm.DeletedAt ` + "`gorm:\"index\" json:\"-\"`" + `
 }
+
+// InvalidValueError 枚举或集合字段的取值不合法，JSON 解析与写入数据库时返回，
+// Service 层的 TranslateError 将其转换为 422 校验错误
+type InvalidValueError struct {
+	Field string
+	Value string
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("无效的%s: %q", e.Field, e.Value)
+}
 `
 
 	t, err := template.New("base").Parse(tmpl)
@@ -435,26 +868,27 @@ type BaseModel struct {
 		return err
 	}
 
-	file, err := os.Create(filepath.Join(g.config.Output, "base.go"))
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return t.Execute(file, map[string]string{
-		"Package": g.config.Package,
+	return writeGoFile(filepath.Join(g.config.Output, "base.go"), t, map[string]string{
+		"Package":   g.config.Package,
+		"ID":        g.toJSONCase("id"),
+		"CreatedAt": g.toJSONCase("created_at"),
+		"UpdatedAt": g.toJSONCase("updated_at"),
 	})
 }
 
 // generateTableModel 生成表模型
 func (g *Generator) generateTableModel(table TableInfo) error {
 	tmpl := `package {{.Package}}
+{{- if .Imports}}
 
 import (
-	"time"
+	{{- range .Imports}}
+	{{.}}
+	{{- end}}
 )
+{{- end}}
 
-// {{.StructName}} {{.Comment}}
+// {{.StructName}} {{.Comment}}{{if .ReadOnly}}{{if .Comment}}（视图，只读）{{else}}视图，只读{{end}}{{end}}
 type {{.StructName}} struct {
 	{{- if .UseBaseModel}}
 	BaseModel
@@ -464,38 +898,216 @@ type {{.StructName}} struct {
 	{{- end}}
 }
 
+{{- if .Shards}}
+
+// TableName 返回逻辑表名，读写分表时使用 {{.StructName}}Scope 指定分表
+func ({{.StructName}}) TableName() string {
+	return "{{.TableName}}"
+}
+
+// {{.StructName}}Shards {{.Comment}}的全部分表
+var {{.StructName}}Shards = []string{
+	{{- range .Shards}}
+	"{{.}}",
+	{{- end}}
+}
+
+{{- if .DefaultShardFunc}}
+
+// {{.StructName}}ShardFunc 根据分片键返回分表名，默认按分片键对分表数取模，取模结果即分表后缀；分片规则不同时可在初始化时替换
+var {{.StructName}}ShardFunc = func(key int64) string {
+	n := key % int64(len({{.StructName}}Shards))
+	if n < 0 {
+		n = -n
+	}
+	return {{.StructName}}Shards[n]
+}
+{{- else}}
+
+// {{.StructName}}ShardFunc 根据分片键返回分表名。分表后缀不是从 0 开始的连续编号，无法按取模推断分表，
+// 需在初始化时设置；未设置时 {{.StructName}}Scope 与 Service 的查询返回错误
+var {{.StructName}}ShardFunc func(key int64) string
+{{- end}}
+
+// {{.StructName}}Scope 将查询限定到分片键所在的分表，如 db.Scopes({{.StructName}}Scope(key)).Find(&list)
+func {{.StructName}}Scope(key int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		{{- if not .DefaultShardFunc}}
+		if {{.StructName}}ShardFunc == nil {
+			db.AddError(errors.New("未设置 {{.StructName}}ShardFunc"))
+			return db
+		}
+		{{- end}}
+		return db.Table({{.StructName}}ShardFunc(key))
+	}
+}
+{{- else}}
+
 // TableName 指定表名
 func ({{.StructName}}) TableName() string {
 	return "{{.TableName}}"
 }
+{{- end}}
+{{- template "enums" .}}
 `
 
-	t, err := template.New("table").Parse(tmpl)
+	t, err := template.New("table").Parse(tmpl + enumTemplate)
 	if err != nil {
 		return err
 	}
 
 	// 准备模板数据
+	enums := g.enumTemplateData(table)
 	data := map[string]interface{}{
 		"Package":      g.config.Package,
-		"StructName":   g.toCamelCase(table.Name),
+		"StructName":   g.modelName(table),
 		"TableName":    table.Name,
 		"Comment":      table.Comment,
-		"UseBaseModel": true,
-		"Columns":      g.prepareColumns(table.Columns),
+		"ReadOnly":     table.IsView,
+		"UseBaseModel": g.useBaseModel(table),
+		"Columns":      g.prepareColumns(g.modelColumns(table)),
+		"Imports":      g.modelImports(table, enums),
+		"Enums":        enums,
+		"Shards":       table.Shards,
+		// 分表后缀不连续时不生成默认分表函数
+		"DefaultShardFunc": contiguousShards(table.Shards),
 	}
 
 	// 生成文件名
-	fileName := g.toSnakeCase(table.Name) + ".go"
+	fileName := g.fileName(table) + ".go"
 	filePath := filepath.Join(g.config.Output, fileName)
 
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
+	return writeGoFile(filePath, t, data)
+}
+
+// baseModelColumns BaseModel 提供的字段
+var baseModelColumns = []string{"id", "created_at", "updated_at", "deleted_at"}
+
+// useBaseModel 判断表是否嵌入 BaseModel：表需包含 BaseModel 的全部字段，且主键为单一整型 id
+func (g *Generator) useBaseModel(table TableInfo) bool {
+	if len(table.PrimaryKeys) != 1 || table.PrimaryKeys[0] != "id" {
+		return false
+	}
+	for _, name := range baseModelColumns {
+		if _, ok := findColumn(table.Columns, name); !ok {
+			return false
+		}
+	}
+	id, _ := findColumn(table.Columns, "id")
+	return id.GoType == "int" || id.GoType == "int64"
+}
+
+// modelColumns 获取模型结构体自身声明的列，嵌入 BaseModel 时排除其已提供的字段
+func (g *Generator) modelColumns(table TableInfo) []ColumnInfo {
+	if !g.useBaseModel(table) {
+		return table.Columns
+	}
+	var result []ColumnInfo
+	for _, col := range table.Columns {
+		if !contains(baseModelColumns, col.Name) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+// fieldName 获取列在模型结构体中的字段名
+func (g *Generator) fieldName(table TableInfo, column string) string {
+	if col, ok := findColumn(table.Columns, column); ok && col.GoName != "" {
+		return col.GoName
+	}
+	return g.toCamelCase(column)
+}
+
+// modelName 获取表的模型结构体名
+func (g *Generator) modelName(table TableInfo) string {
+	if table.GoName != "" {
+		return table.GoName
+	}
+	return g.defaultModelName(table)
+}
+
+// fileName 获取表的生成文件名（不含后缀）
+func (g *Generator) fileName(table TableInfo) string {
+	if table.FileName != "" {
+		return table.FileName
+	}
+	return g.defaultFileName(table)
+}
+
+// routePath 获取表的路由路径（不含前导 /）
+func (g *Generator) routePath(table TableInfo) string {
+	if table.RoutePath != "" {
+		return table.RoutePath
+	}
+	return g.defaultRoutePath(table)
+}
+
+// keyParamFuncs 主键 Go 类型对应的 Router 路径参数解析函数
+var keyParamFuncs = map[string]string{
+	"uint":   "ParamUint",
+	"int":    "ParamInt",
+	"int64":  "ParamInt64",
+	"string": "ParamString",
+}
+
+// getPrimaryKeyFields 获取主键字段，类型由实际主键列推导；主键不存在或类型不支持时返回 nil
+func (g *Generator) getPrimaryKeyFields(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, name := range table.PrimaryKeys {
+		col, ok := findColumn(table.Columns, name)
+		if !ok {
+			return nil
+		}
+		goType := col.GoType
+		if name == "id" && g.useBaseModel(table) {
+			goType = "uint"
+		}
+		paramFunc, ok := keyParamFuncs[goType]
+		if !ok {
+			log.Printf("警告: 表 %s 的主键 %s 类型 %s 不受支持，不生成按主键操作的方法", table.Name, name, goType)
+			return nil
+		}
+		result = append(result, map[string]interface{}{
+			"DBName":    name,
+			"GoName":    g.fieldName(table, name),
+			"GoType":    goType,
+			"VarName":   g.toVarName(g.fieldName(table, name)),
+			"ParamFunc": paramFunc,
+		})
 	}
-	defer file.Close()
+	return result
+}
 
-	return t.Execute(file, data)
+// primaryKeyTemplateData 生成主键相关的模板片段：方法参数、调用实参、WHERE 条件与路由路径。
+// 联合主键额外生成主键结构体类型，供批量操作使用
+func (g *Generator) primaryKeyTemplateData(table TableInfo, keys []map[string]interface{}) map[string]interface{} {
+	var params, args, types, conds, paths, columns []string
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s %s", key["VarName"], key["GoType"]))
+		args = append(args, key["VarName"].(string))
+		types = append(types, key["GoType"].(string))
+		conds = append(conds, fmt.Sprintf("`%s` = ?", key["DBName"]))
+		paths = append(paths, "/:"+key["DBName"].(string))
+		columns = append(columns, fmt.Sprintf("`%s`", key["DBName"]))
+	}
+
+	keyType := keys[0]["GoType"].(string)
+	if len(keys) > 1 {
+		keyType = g.modelName(table) + "Key"
+	}
+
+	return map[string]interface{}{
+		"Keys":     keys,
+		"Params":   strings.Join(params, ", "),
+		"Args":     strings.Join(args, ", "),
+		"Types":    strings.Join(types, ", "),
+		"Where":    strings.Join(conds, " AND "),
+		"Path":     strings.Join(paths, ""),
+		"Columns":  strings.Join(columns, ", "),
+		"KeyType":  keyType,
+		"Multiple": len(keys) > 1,
+	}
 }
 
 // prepareColumns 准备列数据
@@ -503,7 +1115,7 @@ func (g *Generator) prepareColumns(columns []ColumnInfo) []map[string]interface{
 	var result []map[string]interface{}
 	for _, col := range columns {
 		result = append(result, map[string]interface{}{
-			"GoName":  g.toCamelCase(col.Name),
+			"GoName":  g.columnGoName(col),
 			"GoType":  col.GoType,
 			"GoTag":   col.GoTag,
 			"Comment": col.Comment,
@@ -512,13 +1124,109 @@ func (g *Generator) prepareColumns(columns []ColumnInfo) []map[string]interface{
 	return result
 }
 
-// toCamelCase 转换为驼峰命名
-func (g *Generator) toCamelCase(s string) string {
-	parts := strings.Split(s, "_")
-	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+// columnGoName 获取列的字段名，未经 resolveNames 计算时按列名转换
+func (g *Generator) columnGoName(col ColumnInfo) string {
+	if col.GoName != "" {
+		return col.GoName
+	}
+	return g.toCamelCase(col.Name)
+}
+
+// protoName 获取列的 protobuf 字段名，未经 resolveNames 计算时按列名转换为蛇形命名
+func (g *Generator) protoName(col ColumnInfo) string {
+	if col.ProtoName != "" {
+		return col.ProtoName
+	}
+	return asciiIdent(toSnakeCase(col.Name))
+}
+
+// graphQLName 获取列的 GraphQL 字段名，未经 resolveNames 计算时由模型字段名转换
+func (g *Generator) graphQLName(col ColumnInfo) string {
+	if col.GraphQLName != "" {
+		return col.GraphQLName
+	}
+	return asciiIdent(lowerCamel(g.columnGoName(col)))
+}
+
+// jsonName 获取列的 JSON 字段名，未经 resolveNames 计算时按列名转换
+func (g *Generator) jsonName(col ColumnInfo) string {
+	if col.JSONName != "" {
+		return col.JSONName
+	}
+	return g.toJSONCase(col.Name)
+}
+
+// findColumn 按列名查找列
+func findColumn(columns []ColumnInfo, name string) (ColumnInfo, bool) {
+	for _, col := range columns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+	return ColumnInfo{}, false
+}
+
+// contains 判断字符串切片是否包含指定值
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll 判断 list 是否包含 items 中的全部值
+func containsAll(list, items []string) bool {
+	for _, item := range items {
+		if !contains(list, item) {
+			return false
 		}
 	}
-	return strings.Join(parts, "")
+	return true
+}
+
+// needTimeImport 判断字段是否引用 time 包
+func (g *Generator) needTimeImport(columns []ColumnInfo) bool {
+	for _, col := range columns {
+		if strings.TrimPrefix(col.GoType, "*") == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// modelImports 获取模型文件的导入声明（带引号，需要别名时以别名开头）：时间字段、枚举类型的方法、JSON 字段类型与分表 scope 所需的包
+func (g *Generator) modelImports(table TableInfo, enums []map[string]interface{}) []string {
+	var imports []string
+	if len(enums) > 0 {
+		imports = append(imports, "database/sql/driver", "encoding/json", "fmt")
+	}
+	for _, enum := range enums {
+		if enum["Set"].(bool) {
+			imports = append(imports, "strings")
+			break
+		}
+	}
+	if g.needTimeImport(g.modelColumns(table)) {
+		imports = append(imports, "time")
+	}
+	if len(table.Shards) > 0 {
+		if !contiguousShards(table.Shards) {
+			imports = append(imports, "errors")
+		}
+		imports = append(imports, "gorm.io/gorm")
+	}
+	for i, importPath := range imports {
+		imports[i] = strconv.Quote(importPath)
+	}
+	return append(imports, jsonImports(g.modelColumns(table))...)
+}
+
+// modelPackageName 获取模型包名，用于在 Router/Service 中限定模型类型
+func (g *Generator) modelPackageName() string {
+	if g.config.ModelImportPath != "" {
+		return path.Base(g.config.ModelImportPath)
+	}
+	return g.config.Package
 }
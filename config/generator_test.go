@@ -0,0 +1,320 @@
+package generator
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// update 为 true 时用生成结果覆盖 golden 文件：go test ./config -run Golden -update
+var update = flag.Bool("update", false, "用生成结果更新 testdata/golden 下的 golden 文件")
+
+// goldenDir golden 文件目录，文件名为生成文件的相对路径加 .golden 后缀
+const goldenDir = "testdata/golden"
+
+// testColumn 构造列信息，GoType 与 GoTag 与从数据库读取时的计算方式一致
+func testColumn(g *Generator, name, typ string, nullable, primary, autoIncr bool, comment string) ColumnInfo {
+	col := ColumnInfo{
+		Name:         name,
+		Type:         typ,
+		IsNullable:   nullable,
+		IsPrimaryKey: primary,
+		IsAutoIncr:   autoIncr,
+		Comment:      comment,
+	}
+	col.GoType = g.convertToGoType(col.Type, col.IsNullable)
+	col.GoTag = g.generateGoTag(col)
+	return col
+}
+
+// testTables 测试用的表结构，覆盖自增主键、BaseModel、乐观锁、唯一索引、全文索引、外键、枚举与集合、JSON、
+// 复合主键、字符串主键、多租户、无主键表、字段命名冲突、视图与分表
+func testTables(g *Generator) []TableInfo {
+	users := TableInfo{Name: "users", Comment: "用户", PrimaryKeys: []string{"id"}}
+	users.Columns = []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, "ID"),
+		testColumn(g, "username", "varchar", false, false, false, "用户名"),
+		testColumn(g, "email", "varchar", true, false, false, "邮箱"),
+		testColumn(g, "age", "int", true, false, false, "年龄"),
+		testColumn(g, "bio", "text", true, false, false, "简介"),
+		testColumn(g, "score", "decimal", false, false, false, "积分"),
+		testColumn(g, "avatar", "blob", true, false, false, "头像"),
+		testColumn(g, "version", "int", false, false, false, "版本号"),
+		testColumn(g, "created_at", "datetime", false, false, false, ""),
+		testColumn(g, "updated_at", "datetime", false, false, false, ""),
+	}
+	users.Indexes = []IndexInfo{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		{Name: "uk_username", Columns: []string{"username"}, Unique: true, Type: "BTREE"},
+		{Name: "idx_created", Columns: []string{"created_at"}, Type: "BTREE"},
+	}
+
+	tags := TableInfo{Name: "tags", Comment: "标签", PrimaryKeys: []string{"id"}}
+	tags.Columns = []ColumnInfo{
+		testColumn(g, "id", "int", false, true, true, ""),
+		testColumn(g, "label", "varchar", false, false, false, "名称"),
+		testColumn(g, "created_at", "datetime", false, false, false, ""),
+		testColumn(g, "updated_at", "datetime", false, false, false, ""),
+		testColumn(g, "deleted_at", "datetime", true, false, false, ""),
+	}
+
+	articles := TableInfo{Name: "articles", Comment: "文章", PrimaryKeys: []string{"id"}}
+	articles.Columns = []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+		testColumn(g, "title", "varchar", false, false, false, "标题"),
+		testColumn(g, "body", "text", false, false, false, "正文"),
+		testColumn(g, "slug", "varchar", false, false, false, ""),
+		testColumn(g, "author_id", "bigint", true, false, false, "作者"),
+		testColumn(g, "status", "enum", false, false, false, "状态"),
+		testColumn(g, "labels", "set", true, false, false, "标签"),
+		testColumn(g, "meta", "json", false, false, false, "元数据"),
+		testColumn(g, "extra", "json", true, false, false, "扩展信息"),
+		testColumn(g, "settings", "json", true, false, false, "设置"),
+	}
+	articles.Columns[5].EnumValues = []string{"draft", "published", "in review"}
+	articles.Columns[6].EnumValues = []string{"hot", "new"}
+	articles.Indexes = []IndexInfo{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		{Name: "ft_title_body", Columns: []string{"title", "body"}, Type: "FULLTEXT"},
+	}
+	articles.ForeignKeys = []ForeignKeyInfo{{Column: "author_id", RefTable: "users", RefColumn: "id"}}
+
+	orderItems := TableInfo{Name: "order_items", Comment: "订单明细", PrimaryKeys: []string{"tenant_id", "id"}}
+	orderItems.Columns = []ColumnInfo{
+		testColumn(g, "tenant_id", "bigint", false, true, false, "租户"),
+		testColumn(g, "id", "bigint", false, true, false, ""),
+		testColumn(g, "sku", "varchar", false, false, false, ""),
+		testColumn(g, "qty", "int", false, false, false, ""),
+	}
+	orderItems.Indexes = []IndexInfo{{Name: "PRIMARY", Columns: []string{"tenant_id", "id"}, Unique: true, Type: "BTREE"}}
+
+	sessions := TableInfo{Name: "sessions", Comment: "会话", PrimaryKeys: []string{"token"}}
+	sessions.Columns = []ColumnInfo{
+		testColumn(g, "token", "varchar", false, true, false, ""),
+		testColumn(g, "user_id", "bigint", false, false, false, ""),
+		testColumn(g, "expires_at", "datetime", false, false, false, ""),
+	}
+	sessions.ForeignKeys = []ForeignKeyInfo{{Column: "user_id", RefTable: "users", RefColumn: "id"}}
+
+	projects := TableInfo{Name: "projects", Comment: "项目", PrimaryKeys: []string{"id"}}
+	projects.Columns = []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+		testColumn(g, "tenant_id", "bigint", false, false, false, "租户"),
+		testColumn(g, "name", "varchar", false, false, false, "名称"),
+	}
+
+	logs := TableInfo{Name: "logs", Comment: "日志"}
+	logs.Columns = []ColumnInfo{
+		testColumn(g, "message", "varchar", false, false, false, ""),
+	}
+
+	// 列名为 Go 关键字、含缩写词，以数字或中文开头，或与 TableName 方法、其他列的字段名冲突
+	apiKeys := TableInfo{Name: "api_keys", Comment: "API 密钥", PrimaryKeys: []string{"id"}}
+	apiKeys.Columns = []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+		testColumn(g, "type", "varchar", false, false, false, "类型（唯一）"),
+		testColumn(g, "user_id", "bigint", false, false, false, ""),
+		testColumn(g, "userId", "bigint", true, false, false, ""),
+		testColumn(g, "table_name", "varchar", true, false, false, ""),
+		testColumn(g, "callback_url", "varchar", true, false, false, ""),
+		testColumn(g, "1st_place", "int", true, false, false, ""),
+		testColumn(g, "用户名", "varchar", true, false, false, ""),
+		testColumn(g, "备注", "varchar", true, false, false, ""),
+	}
+
+	// 分表 orders_0、orders_2、orders_10 合并为逻辑表 orders（按自然顺序排列分表），包含多租户字段
+	var orders []TableInfo
+	for _, name := range []string{"orders_0", "orders_10", "orders_2"} {
+		shard := TableInfo{Name: name, Comment: "订单", PrimaryKeys: []string{"id"}}
+		shard.Columns = []ColumnInfo{
+			testColumn(g, "id", "bigint", false, true, true, ""),
+			testColumn(g, "tenant_id", "bigint", false, false, false, "租户"),
+			testColumn(g, "user_id", "bigint", false, false, false, ""),
+			testColumn(g, "amount", "decimal", false, false, false, "金额"),
+		}
+		orders = append(orders, shard)
+	}
+
+	// 视图没有主键信息，按 id 列推断主键
+	activeUsers := TableInfo{Name: "active_users", Comment: "活跃用户", IsView: true}
+	activeUsers.Columns = []ColumnInfo{
+		testColumn(g, "id", "bigint", false, false, false, ""),
+		testColumn(g, "tenant_id", "bigint", false, false, false, "租户"),
+		testColumn(g, "username", "varchar", false, false, false, "用户名"),
+		testColumn(g, "version", "int", false, false, false, ""),
+	}
+
+	return append([]TableInfo{users, tags, articles, orderItems, sessions, projects, logs, apiKeys, activeUsers}, orders...)
+}
+
+// testConfig 输出到 root 下 internal 与 web 目录的配置，导入路径以 example.com/app 为模块
+func testConfig(root string) *Config {
+	internal := filepath.Join(root, "internal")
+	return &Config{
+		Output:            filepath.Join(internal, "models"),
+		Package:           "models",
+		ModelImportPath:   "example.com/app/internal/models",
+		StorageImportPath: "example.com/app/internal/storage",
+		GenerateService:   true,
+		ServiceOutput:     filepath.Join(internal, "services"),
+		ServiceImportPath: "example.com/app/internal/services",
+		GenerateRouter:    true,
+		RouterOutput:      filepath.Join(internal, "router"),
+		GenerateTests:     true,
+		TenantColumn:      "tenant_id",
+		VersionColumns:    []string{"version"},
+		CursorColumns:     map[string]string{"users": "created_at"},
+		SearchColumns:     map[string][]string{"articles": {"title", "body", "slug"}},
+		Operations:        map[string][]string{"logs": {"read"}, "sessions": {"get", "create"}, "tags": {}},
+		ShardPatterns:     []string{`^(orders)_\d+$`},
+		JSONTypes: map[string]string{
+			"articles.meta":     "example.com/app/internal/types.ArticleMeta",
+			"articles.settings": "example.com/app/internal/settings.v1.Settings",
+		},
+	}
+}
+
+// generateTestTree 用测试表结构按 cfg 生成代码
+func generateTestTree(t *testing.T, cfg *Config) {
+	t.Helper()
+	g := NewGenerator(cfg)
+	if err := g.generate(testTables(g)); err != nil {
+		t.Fatalf("生成代码失败: %v", err)
+	}
+}
+
+// listFiles 返回 root 下全部文件的相对路径（已排序，使用 / 分隔）
+func listFiles(t *testing.T, root string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("遍历目录 %s 失败: %v", root, err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// testFullConfig 在 testConfig 基础上同时生成 gRPC、GraphQL 与 TypeScript 代码
+func testFullConfig(root string) *Config {
+	cfg := testConfig(root)
+	cfg.GenerateGRPC = true
+	cfg.GRPCOutput = filepath.Join(root, "internal", "grpcserver")
+	cfg.ProtoOutput = filepath.Join(root, "internal", "pb")
+	cfg.ProtoImportPath = "example.com/app/internal/pb"
+	cfg.ProtoPackage = "app.v1"
+	cfg.GenerateGraphQL = true
+	cfg.GraphQLOutput = filepath.Join(root, "internal", "graph")
+	cfg.GraphQLImportPath = "example.com/app/internal/graph"
+	cfg.TSOutput = filepath.Join(root, "web", "api")
+	return cfg
+}
+
+func TestGolden(t *testing.T) {
+	root := t.TempDir()
+	generateTestTree(t, testFullConfig(root))
+
+	generated := listFiles(t, root)
+	if *update {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range generated {
+			data, err := os.ReadFile(filepath.Join(root, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join(goldenDir, name+".golden")
+			if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(golden, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	// 生成文件与 golden 文件必须一一对应
+	var goldens []string
+	for _, name := range listFiles(t, goldenDir) {
+		goldens = append(goldens, strings.TrimSuffix(name, ".golden"))
+	}
+	if strings.Join(generated, "\n") != strings.Join(goldens, "\n") {
+		t.Fatalf("生成的文件列表与 golden 不一致（使用 -update 更新）\n生成:\n  %s\ngolden:\n  %s",
+			strings.Join(generated, "\n  "), strings.Join(goldens, "\n  "))
+	}
+
+	for _, name := range generated {
+		got, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(filepath.Join(goldenDir, name+".golden"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s 与 golden 不一致（使用 -update 更新）:\n%s", name, firstDiff(string(want), string(got)))
+		}
+		if strings.HasSuffix(name, ".go") {
+			checkGoFormat(t, name, got)
+		}
+	}
+}
+
+// checkGoFormat 检查生成的 Go 代码已按 gofmt 格式化，且顶层声明之间有空行
+// （gofmt 不会补充空行，模板中的 {{- 与 -}} 容易误删）
+func checkGoFormat(t *testing.T, name string, src []byte) {
+	t.Helper()
+	formatted, err := format.Source(src)
+	if err != nil {
+		t.Errorf("%s 无法解析: %v", name, err)
+		return
+	}
+	if !bytes.Equal(formatted, src) {
+		t.Errorf("%s 未按 gofmt 格式化:\n%s", name, firstDiff(string(formatted), string(src)))
+	}
+	lines := strings.Split(string(src), "\n")
+	for i := 1; i < len(lines); i++ {
+		if (lines[i-1] == "}" || lines[i-1] == ")") && lines[i] != "" && !strings.HasPrefix(lines[i], "\t") {
+			t.Errorf("%s 第 %d 行: 顶层声明之间缺少空行", name, i+1)
+		}
+	}
+}
+
+// firstDiff 返回两段文本第一处不同的行
+func firstDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("第 %d 行\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+	return ""
+}
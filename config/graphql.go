@@ -0,0 +1,874 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// GraphQLPackage 生成的 GraphQL 解析器代码包名
+const GraphQLPackage = "graph"
+
+// graphQLTypes Go 类型到 GraphQL 类型的映射，指针类型使用对应的可空类型
+var graphQLTypes = map[string]string{
+	"int":       "Int",
+	"int64":     "Int64",
+	"uint":      "Uint",
+	"float64":   "Float",
+	"string":    "String",
+	"bool":      "Boolean",
+	"time.Time": "Time",
+}
+
+// generateGraphQL 生成 GraphQL SDL、gqlgen 配置与调用 Service 层的解析器
+func (g *Generator) generateGraphQL(tables []TableInfo) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(g.config.GraphQLOutput, "schema"), 0755); err != nil {
+		return fmt.Errorf("创建 GraphQL 输出目录失败: %w", err)
+	}
+
+	data := g.graphQLTemplateData(tables)
+	type outputFile struct {
+		name string
+		tmpl string
+		data interface{}
+	}
+	files := []outputFile{
+		{"gqlgen.yml", gqlgenConfigTemplate, data},
+		{filepath.Join("schema", "schema.graphqls"), graphQLSchemaTemplate, data},
+		{"resolver.go", graphQLResolverTemplate, data},
+		{"schema.resolvers.go", graphQLRootResolverTemplate, data},
+	}
+	for _, table := range data["Tables"].([]map[string]interface{}) {
+		files = append(files, outputFile{filepath.Join("schema", table["FileName"].(string)+".graphqls"), graphQLTypeTemplate, table})
+		if len(table["Relations"].([]map[string]interface{})) > 0 || len(table["Enums"].([]map[string]interface{})) > 0 ||
+			len(table["JSONFields"].([]map[string]interface{})) > 0 {
+			files = append(files, outputFile{table["FileName"].(string) + ".resolvers.go", graphQLTypeResolverTemplate, table})
+		}
+	}
+
+	for _, f := range files {
+		if err := g.executeTemplate(filepath.Join(g.config.GraphQLOutput, f.name), f.tmpl, f.data); err != nil {
+			return fmt.Errorf("生成 %s 失败: %w", f.name, err)
+		}
+	}
+	fmt.Println("生成 GraphQL 代码成功")
+
+	return nil
+}
+
+// executeTemplate 渲染模板并写入文件，Go 文件经 gofmt 格式化
+func (g *Generator) executeTemplate(fileName, tmpl string, data interface{}) error {
+	t, err := template.New(filepath.Base(fileName)).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(fileName, ".go") {
+		return writeGoFile(fileName, t, data)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return t.Execute(file, data)
+}
+
+// graphQLTemplateData 准备 GraphQL 模板数据
+func (g *Generator) graphQLTemplateData(tables []TableInfo) map[string]interface{} {
+	byName := make(map[string]TableInfo, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+
+	var items []map[string]interface{}
+	needTime, hasMutation := false, false
+	for _, table := range tables {
+		item := g.graphQLTable(table, tables, byName)
+		if item["NeedTime"].(bool) {
+			needTime = true
+		}
+		if !table.IsView {
+			hasMutation = true
+		}
+		items = append(items, item)
+	}
+
+	return map[string]interface{}{
+		"Package":        GraphQLPackage,
+		"ModelPackage":   g.config.ModelImportPath,
+		"ServicePackage": g.config.ServiceImportPath,
+		"GraphQLPackage": g.config.GraphQLImportPath,
+		"Tables":         items,
+		"NeedTime":       needTime,
+		"HasMutation":    hasMutation,
+	}
+}
+
+// graphQLTable 准备单表的 GraphQL 模板数据
+func (g *Generator) graphQLTable(table TableInfo, tables []TableInfo, byName map[string]TableInfo) map[string]interface{} {
+	typeName := g.modelName(table)
+	fields := g.getGraphQLFields(table)
+
+	// 输入字段：排除自增主键与 GORM 自动维护的时间字段
+	var inputFields []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		if col.IsAutoIncr || (col.Name != "id" && contains(baseModelColumns, col.Name)) {
+			continue
+		}
+		gqlType, ok := g.graphQLType(table, col)
+		if !ok {
+			continue
+		}
+		inputFields = append(inputFields, map[string]interface{}{
+			"Name": g.graphQLName(col),
+			"Type": gqlType,
+		})
+	}
+
+	var keys []map[string]interface{}
+	var keyArgs, keyParams []string
+	for _, key := range g.getPrimaryKeyFields(table) {
+		name := asciiIdent(lowerCamel(key["DBName"].(string)))
+		keys = append(keys, map[string]interface{}{
+			"Name": name,
+			"Type": graphQLTypes[key["GoType"].(string)] + "!",
+		})
+		param := gqlgenName(key["DBName"].(string), false)
+		keyArgs = append(keyArgs, param)
+		keyParams = append(keyParams, param+" "+key["GoType"].(string))
+	}
+
+	// 字段名与模型字段名不一致（忽略大小写）时 gqlgen 无法自动绑定，如中文列名，需在 gqlgen.yml 中指定
+	var fieldMap []map[string]interface{}
+	for _, col := range table.Columns {
+		if name := g.graphQLName(col); !strings.EqualFold(name, g.columnGoName(col)) {
+			fieldMap = append(fieldMap, map[string]interface{}{"Name": name, "GoName": g.columnGoName(col)})
+		}
+	}
+
+	relations := g.getGraphQLRelations(table, tables, byName, fields)
+	editable := g.getEditableFields(table)
+	enums := g.getGraphQLEnums(table)
+	jsonFields := g.getGraphQLJSONFields(table)
+	inputResolvers := (len(enums) > 0 || len(jsonFields) > 0) && !table.IsView
+
+	return map[string]interface{}{
+		"Package":          GraphQLPackage,
+		"ModelPackage":     g.config.ModelImportPath,
+		"ServicePackage":   g.config.ServiceImportPath,
+		"TableName":        table.Name,
+		"FileName":         g.fileName(table),
+		"TypeName":         typeName,
+		"GoName":           gqlgenName(lowerCamel(g.modelName(table)), true),
+		"FieldName":        lowerCamel(g.modelName(table)),
+		"RepoName":         g.pluralName(table),
+		"ResolverName":     g.toLowerCamelCase(g.modelName(table)) + "Resolver",
+		"ModelType":        g.modelPackageName() + "." + typeName,
+		"Comment":          table.Comment,
+		"Fields":           fields,
+		"InputFields":      inputFields,
+		"FieldMap":         fieldMap,
+		"Relations":        relations,
+		"Enums":            enums,
+		"JSONFields":       jsonFields,
+		"InputResolvers":   inputResolvers,
+		"InputResolver":    g.toLowerCamelCase(g.modelName(table)) + "InputResolver",
+		"Keys":             keys,
+		"KeyArgs":          strings.Join(keyArgs, ", "),
+		"KeyParams":        strings.Join(keyParams, ", "),
+		"PK":               len(keys) > 0,
+		"UpdateableFields": editable,
+		"Version":          g.getVersionColumn(table),
+		"NeedTime":         len(keys) > 0 && !table.IsView && g.needTimeZeroValue(editable),
+		"ReadOnly":         table.IsView,
+		"NeedFmt":          hasRelationKind(relations, "many") || inputResolvers,
+		"NeedJSON":         hasCustomJSON(jsonFields) || (len(jsonFields) > 0 && !table.IsView),
+	}
+}
+
+// getGraphQLFields 获取表的 GraphQL 字段，不支持的类型与 BaseModel 的软删除字段被跳过。
+// 字段名由模型字段名转换，gqlgen 按名称（忽略大小写）将其绑定到对应的模型字段
+func (g *Generator) getGraphQLFields(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range table.Columns {
+		if g.useBaseModel(table) && col.Name == "deleted_at" {
+			continue
+		}
+		goType := g.modelGoType(table, col)
+		gqlType, ok := g.graphQLType(table, col)
+		if !ok {
+			log.Printf("警告: 表 %s 的字段 %s 类型 %s 无法映射为 GraphQL 类型，已跳过", table.Name, col.Name, col.GoType)
+			continue
+		}
+		// JSON 列可为空时对应 null，自定义类型的指针不表示可空
+		if (col.JSON == nil && !strings.HasPrefix(goType, "*")) || (col.JSON != nil && !col.IsNullable) {
+			gqlType += "!"
+		}
+		result = append(result, map[string]interface{}{
+			"DBName":  col.Name,
+			"Name":    g.graphQLName(col),
+			"Type":    gqlType,
+			"Comment": col.Comment,
+		})
+	}
+	return result
+}
+
+// graphQLType 获取字段的 GraphQL 类型（不含非空标记），枚举为 String，集合为 [String!]
+func (g *Generator) graphQLType(table TableInfo, col ColumnInfo) (string, bool) {
+	if col.Enum != nil {
+		if col.Enum.Set {
+			return "[String!]", true
+		}
+		return "String", true
+	}
+	if col.JSON != nil {
+		return "String", true
+	}
+	gqlType, ok := graphQLTypes[strings.TrimPrefix(g.modelGoType(table, col), "*")]
+	return gqlType, ok
+}
+
+// getGraphQLEnums 获取枚举与集合字段：gqlgen 无法将 String 直接绑定到模型中的枚举类型，
+// 由字段解析器在取值字符串与枚举类型之间转换，输入时校验取值
+func (g *Generator) getGraphQLEnums(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		if col.Enum == nil {
+			continue
+		}
+		comment := col.Comment
+		if comment == "" {
+			comment = col.Name
+		}
+		result = append(result, map[string]interface{}{
+			"MethodName": gqlgenName(g.graphQLName(col), true),
+			"GoName":     g.columnGoName(col),
+			"TypeName":   g.modelPackageName() + "." + col.Enum.TypeName,
+			"ElemName":   g.modelPackageName() + "." + col.Enum.ElemName,
+			"Set":        col.Enum.Set,
+			"Comment":    comment,
+		})
+	}
+	return result
+}
+
+// getGraphQLJSONFields 获取 JSON 字段：GraphQL 中以 JSON 字符串表示，由字段解析器编码，输入时校验格式
+func (g *Generator) getGraphQLJSONFields(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		if col.JSON == nil {
+			continue
+		}
+		comment := col.Comment
+		if comment == "" {
+			comment = col.Name
+		}
+		result = append(result, map[string]interface{}{
+			"MethodName": gqlgenName(g.graphQLName(col), true),
+			"GoName":     g.columnGoName(col),
+			"Custom":     col.JSON.Custom,
+			"Nullable":   col.IsNullable,
+			"Comment":    comment,
+		})
+	}
+	return result
+}
+
+// hasCustomJSON 判断是否有自定义类型的 JSON 字段
+func hasCustomJSON(fields []map[string]interface{}) bool {
+	for _, field := range fields {
+		if field["Custom"].(bool) {
+			return true
+		}
+	}
+	return false
+}
+
+// getGraphQLRelations 根据外键生成关联字段：本表外键指向的记录（one），以及引用本表主键的其他表记录（many）。
+// 仅支持引用单列主键的外键
+func (g *Generator) getGraphQLRelations(table TableInfo, tables []TableInfo, byName map[string]TableInfo, fields []map[string]interface{}) []map[string]interface{} {
+	used := make(map[string]bool)
+	for _, field := range fields {
+		used[field["Name"].(string)] = true
+	}
+
+	var result []map[string]interface{}
+	add := func(relation map[string]interface{}) {
+		name := relation["Name"].(string)
+		if used[name] {
+			log.Printf("警告: 表 %s 的关联字段 %s 与已有字段重名，已跳过", table.Name, name)
+			return
+		}
+		used[name] = true
+		relation["MethodName"] = gqlgenName(relation["Source"].(string), true)
+		result = append(result, relation)
+	}
+
+	// 本表外键指向的记录
+	for _, fk := range table.ForeignKeys {
+		ref, ok := byName[fk.RefTable]
+		if !ok {
+			continue
+		}
+		keys := g.getPrimaryKeyFields(ref)
+		if len(keys) != 1 || keys[0]["DBName"] != fk.RefColumn {
+			continue
+		}
+		col, ok := findColumn(table.Columns, fk.Column)
+		if !ok {
+			continue
+		}
+		keyType := keys[0]["GoType"].(string)
+		value := "obj." + g.fieldName(table, col.Name)
+		nullable := strings.HasPrefix(col.GoType, "*")
+		if nullable {
+			value = "*" + value
+		}
+		if strings.TrimPrefix(col.GoType, "*") != keyType {
+			value = keyType + "(" + value + ")"
+		}
+
+		source := strings.TrimSuffix(fk.Column, "_id")
+		if !strings.HasSuffix(fk.Column, "_id") {
+			source += "_ref"
+		}
+		add(map[string]interface{}{
+			"Kind":      "one",
+			"Name":      lowerCamel(source),
+			"Source":    source,
+			"Type":      g.modelName(ref),
+			"ModelType": g.modelPackageName() + "." + g.modelName(ref),
+			"RepoName":  g.pluralName(ref),
+			"Field":     "obj." + g.fieldName(table, col.Name),
+			"Nullable":  nullable,
+			"Value":     value,
+		})
+	}
+
+	// 引用本表主键的其他表记录
+	keys := g.getPrimaryKeyFields(table)
+	if len(keys) != 1 {
+		return result
+	}
+	for _, other := range tables {
+		var refs []ForeignKeyInfo
+		for _, fk := range other.ForeignKeys {
+			if fk.RefTable == table.Name && fk.RefColumn == keys[0]["DBName"] {
+				refs = append(refs, fk)
+			}
+		}
+		for _, fk := range refs {
+			source := other.Name
+			if len(refs) > 1 {
+				source += "_by_" + fk.Column
+			}
+			add(map[string]interface{}{
+				"Kind":      "many",
+				"Name":      lowerCamel(source),
+				"Source":    source,
+				"Type":      g.modelName(other),
+				"ModelType": g.modelPackageName() + "." + g.modelName(other),
+				"RepoName":  g.pluralName(other),
+				"Column":    fk.Column,
+				"Value":     "obj." + keys[0]["GoName"].(string),
+			})
+		}
+	}
+	return result
+}
+
+// gqlgenInitialisms gqlgen 生成 Go 名称时转为全大写的缩写词
+var gqlgenInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "AWS": true, "CPU": true, "CSS": true, "CSV": true,
+	"DNS": true, "EOF": true, "GCP": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ICMP": true, "ID": true, "IP": true, "JSON": true, "KVK": true, "LHS": true, "PDF": true,
+	"PGP": true, "QPS": true, "QR": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "SVG": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "URI": true, "URL": true, "UTF8": true, "UUID": true,
+	"VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// gqlgenName 按 gqlgen 的规则将下划线或驼峰命名转换为解析器方法名（exported）或参数名。
+// gqlgen 重新生成解析器时会按此规则改写方法签名，生成的方法体必须使用相同的名称
+func gqlgenName(name string, exported bool) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		word = strings.ToLower(word)
+		switch {
+		case !exported && b.Len() == 0:
+		case gqlgenInitialisms[strings.ToUpper(word)]:
+			word = strings.ToUpper(word)
+		default:
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	result := b.String()
+	if !exported && goKeywords[result] {
+		result += "Arg"
+	}
+	return result
+}
+
+// hasRelationKind 检查是否存在指定类型的关联
+func hasRelationKind(relations []map[string]interface{}, kind string) bool {
+	for _, relation := range relations {
+		if relation["Kind"] == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// gqlgenConfigTemplate gqlgen 配置，模型直接绑定到生成的 GORM 模型
+const gqlgenConfigTemplate = `# 由生成器生成，执行 go run github.com/99designs/gqlgen generate 生成 GraphQL 执行代码
+schema:
+  - schema/*.graphqls
+
+exec:
+  filename: generated.go
+  package: {{.Package}}
+
+model:
+  filename: models_gen.go
+  package: {{.Package}}
+
+resolver:
+  layout: follow-schema
+  dir: .
+  package: {{.Package}}
+  filename_template: "{name}.resolvers.go"
+
+models:
+  Int64:
+    model: github.com/99designs/gqlgen/graphql.Int64
+  Uint:
+    model: github.com/99designs/gqlgen/graphql.Uint
+  FilterInput:
+    model: {{.ServicePackage}}.Filter
+{{- range .Tables}}
+  {{.TypeName}}:
+    model: {{.ModelPackage}}.{{.TypeName}}
+    {{- template "fields" .FieldMap}}
+  {{- if not .ReadOnly}}
+  {{.TypeName}}Input:
+    model: {{.ModelPackage}}.{{.TypeName}}
+    {{- template "fields" .FieldMap}}
+  {{- end}}
+  {{.TypeName}}Page:
+    model: {{$.GraphQLPackage}}.{{.TypeName}}Page
+{{- end}}
+{{- define "fields"}}
+{{- if .}}
+    fields:
+    {{- range .}}
+      {{.Name}}:
+        fieldName: {{.GoName}}
+    {{- end}}
+{{- end}}
+{{- end}}
+`
+
+// graphQLSchemaTemplate 公共标量、过滤条件与 Query、Mutation 根类型
+const graphQLSchemaTemplate = `scalar Int64
+scalar Uint
+scalar Time
+
+"""列表过滤条件，op 可选 eq、in、gt、gte、lt、lte、like"""
+input FilterInput {
+  column: String!
+  op: String!
+  values: [String!]!
+}
+
+type Query {
+{{- range .Tables}}
+{{- if .PK}}
+  """获取{{.Comment}}，不存在时返回 null"""
+  {{.FieldName}}({{range $i, $k := .Keys}}{{if $i}}, {{end}}{{$k.Name}}: {{$k.Type}}{{end}}): {{.TypeName}}
+{{- end}}
+  """分页获取{{.Comment}}列表，sort 为逗号分隔的字段名，- 前缀表示倒序"""
+  {{.FieldName}}List(page: Int, pageSize: Int, sort: String, filters: [FilterInput!]): {{.TypeName}}Page!
+{{- end}}
+}
+
+{{- if .HasMutation}}
+
+type Mutation {
+{{- range .Tables}}
+{{- if not .ReadOnly}}
+  """创建{{.Comment}}"""
+  create{{.TypeName}}(input: {{.TypeName}}Input!): {{.TypeName}}!
+{{- if .PK}}
+  """更新{{.Comment}}，仅更新 input 中的非零值字段"""
+  update{{.TypeName}}({{range .Keys}}{{.Name}}: {{.Type}}, {{end}}input: {{.TypeName}}Input!): {{.TypeName}}!
+  """删除{{.Comment}}"""
+  delete{{.TypeName}}({{range $i, $k := .Keys}}{{if $i}}, {{end}}{{$k.Name}}: {{$k.Type}}{{end}}): Boolean!
+{{- end}}
+{{- end}}
+{{- end}}
+}
+{{- end}}
+`
+
+// graphQLTypeTemplate 单表的类型、输入与分页类型
+const graphQLTypeTemplate = `"""{{.Comment}}"""
+type {{.TypeName}} {
+{{- range .Fields}}
+{{- if .Comment}}
+  """{{.Comment}}"""
+{{- end}}
+  {{.Name}}: {{.Type}}
+{{- end}}
+{{- range .Relations}}
+{{- if eq .Kind "one"}}
+  {{.Name}}: {{.Type}}
+{{- else}}
+  {{.Name}}(page: Int, pageSize: Int, sort: String): [{{.Type}}!]!
+{{- end}}
+{{- end}}
+}
+
+{{- if not .ReadOnly}}
+
+input {{.TypeName}}Input {
+{{- range .InputFields}}
+  {{.Name}}: {{.Type}}
+{{- end}}
+}
+{{- end}}
+
+type {{.TypeName}}Page {
+  items: [{{.TypeName}}!]!
+  total: Int64!
+  page: Int!
+  pageSize: Int!
+}
+`
+
+// graphQLResolverTemplate 解析器根对象、分页类型与公共函数
+const graphQLResolverTemplate = `package {{.Package}}
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"{{.ModelPackage}}"
+	"{{.ServicePackage}}"
+)
+
+// Resolver GraphQL 解析器根对象，通过 Repos 调用 Service 层
+type Resolver struct {
+	Repos services.Repos
+}
+
+// NewResolver 创建解析器，例如 NewResolver(services.NewRepos(db))
+func NewResolver(repos services.Repos) *Resolver {
+	return &Resolver{Repos: repos}
+}
+{{- range .Tables}}
+
+// {{.TypeName}}Page {{.Comment}}分页结果
+type {{.TypeName}}Page struct {
+	Items    []{{.ModelType}} ` + "`json:\"items\"`" + `
+	Total    int64 ` + "`json:\"total\"`" + `
+	Page     int ` + "`json:\"page\"`" + `
+	PageSize int ` + "`json:\"pageSize\"`" + `
+}
+{{- end}}
+
+// listQuery 将分页、排序与过滤参数转换为 services.ListQuery
+func listQuery(page, pageSize *int, sort *string, filters []*services.Filter) services.ListQuery {
+	q := services.ListQuery{Page: 1, PageSize: 10}
+	if page != nil && *page > 0 {
+		q.Page = *page
+	}
+	if pageSize != nil && *pageSize > 0 {
+		q.PageSize = *pageSize
+	}
+	if sort != nil {
+		for _, part := range strings.Split(*sort, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				q.Sorts = append(q.Sorts, services.SortField{
+					Column: strings.TrimPrefix(part, "-"),
+					Desc:   strings.HasPrefix(part, "-"),
+				})
+			}
+		}
+	}
+	for _, f := range filters {
+		if f != nil {
+			q.Filters = append(q.Filters, *f)
+		}
+	}
+	return q
+}
+
+// toGraphQLError 将 Service 错误转换为返回给客户端的错误，无法识别的错误只记录日志，不暴露细节
+func toGraphQLError(err error) error {
+	err = services.TranslateError(err)
+	var serviceErr services.ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr
+	}
+	log.Printf("GraphQL 解析失败: %v", err)
+	return services.ErrInternal
+}
+`
+
+// graphQLRootResolverTemplate Query 与 Mutation 解析器
+const graphQLRootResolverTemplate = `package {{.Package}}
+
+import (
+	"context"
+	{{- if .NeedTime}}
+	"time"
+	{{- end}}
+
+	"{{.ModelPackage}}"
+	"{{.ServicePackage}}"
+)
+{{- range .Tables}}
+{{- $t := .}}
+{{- if .PK}}
+
+// {{.GoName}} 获取{{.Comment}}
+func (r *queryResolver) {{.GoName}}(ctx context.Context, {{.KeyParams}}) (*{{.ModelType}}, error) {
+	m, err := r.Repos.{{.RepoName}}.GetByID(ctx, {{.KeyArgs}})
+	if err != nil {
+		if services.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, toGraphQLError(err)
+	}
+	return m, nil
+}
+{{- end}}
+
+// {{.GoName}}List 分页获取{{.Comment}}列表
+func (r *queryResolver) {{.GoName}}List(ctx context.Context, page *int, pageSize *int, sort *string, filters []*services.Filter) (*{{.TypeName}}Page, error) {
+	q := listQuery(page, pageSize, sort, filters)
+	items, total, err := r.Repos.{{.RepoName}}.List(ctx, q)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return &{{.TypeName}}Page{Items: items, Total: total, Page: q.Page, PageSize: q.PageSize}, nil
+}
+{{- if not .ReadOnly}}
+
+// Create{{.GoName}} 创建{{.Comment}}
+func (r *mutationResolver) Create{{.GoName}}(ctx context.Context, input {{.ModelType}}) (*{{.ModelType}}, error) {
+	if err := r.Repos.{{.RepoName}}.Create(ctx, &input); err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return &input, nil
+}
+{{- if .PK}}
+
+// Update{{.GoName}} 更新{{.Comment}}，仅更新 input 中的非零值字段
+func (r *mutationResolver) Update{{.GoName}}(ctx context.Context, {{.KeyParams}}, input {{.ModelType}}) (*{{.ModelType}}, error) {
+	m, err := r.Repos.{{.RepoName}}.GetByID(ctx, {{.KeyArgs}})
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	{{- range .UpdateableFields}}
+	if input.{{.GoName}} != {{.ZeroValue}} {
+		m.{{.GoName}} = input.{{.GoName}}
+	}
+	{{- end}}
+	{{- if .Version}}
+	// 乐观锁：使用客户端提交的版本号
+	m.{{.Version.GoName}} = input.{{.Version.GoName}}
+	{{- end}}
+	if err := r.Repos.{{.RepoName}}.Update(ctx, m); err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return m, nil
+}
+
+// Delete{{.GoName}} 删除{{.Comment}}
+func (r *mutationResolver) Delete{{.GoName}}(ctx context.Context, {{.KeyParams}}) (bool, error) {
+	if err := r.Repos.{{.RepoName}}.Delete(ctx, {{.KeyArgs}}); err != nil {
+		return false, toGraphQLError(err)
+	}
+	return true, nil
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .HasMutation}}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+{{- end}}
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+{{- if .HasMutation}}
+
+type mutationResolver struct{ *Resolver }
+{{- end}}
+type queryResolver struct{ *Resolver }
+`
+
+// graphQLTypeResolverTemplate 关联字段以及枚举、JSON 字段的解析器
+const graphQLTypeResolverTemplate = `package {{.Package}}
+
+import (
+	"context"
+	{{- if .NeedJSON}}
+	"encoding/json"
+	{{- end}}
+	{{- if .NeedFmt}}
+	"fmt"
+	{{- end}}
+
+	"{{.ModelPackage}}"
+	{{- if .Relations}}
+	"{{.ServicePackage}}"
+	{{- end}}
+)
+{{- range .Relations}}
+{{- if eq .Kind "one"}}
+
+// {{.MethodName}} 获取{{$.Comment}}关联的 {{.Type}}
+func (r *{{$.ResolverName}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}) (*{{.ModelType}}, error) {
+	{{- if .Nullable}}
+	if {{.Field}} == nil {
+		return nil, nil
+	}
+	{{- end}}
+	m, err := r.Repos.{{.RepoName}}.GetByID(ctx, {{.Value}})
+	if err != nil {
+		if services.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, toGraphQLError(err)
+	}
+	return m, nil
+}
+{{- else}}
+
+// {{.MethodName}} 分页获取引用该{{$.Comment}}的 {{.Type}}
+func (r *{{$.ResolverName}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}, page *int, pageSize *int, sort *string) ([]*{{.ModelType}}, error) {
+	q := listQuery(page, pageSize, sort, nil)
+	q.SkipTotal = true
+	q.Filters = append(q.Filters, services.Filter{Column: "{{.Column}}", Op: services.OpEq, Values: []string{fmt.Sprint({{.Value}})}})
+	items, _, err := r.Repos.{{.RepoName}}.List(ctx, q)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	result := make([]*{{.ModelType}}, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result, nil
+}
+{{- end}}
+{{- end}}
+{{- range .Enums}}
+
+// {{.MethodName}} 返回{{.Comment}}的取值字符串
+{{- if .Set}}
+func (r *{{$.ResolverName}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}) ([]string, error) {
+	return obj.{{.GoName}}.Strings(), nil
+}
+{{- else}}
+func (r *{{$.ResolverName}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}) (string, error) {
+	return string(obj.{{.GoName}}), nil
+}
+{{- end}}
+{{- end}}
+{{- range .JSONFields}}
+
+// {{.MethodName}} 返回{{.Comment}}的 JSON 字符串
+func (r *{{$.ResolverName}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}) ({{if .Nullable}}*{{end}}string, error) {
+	{{- if .Nullable}}
+	if obj.{{.GoName}} == nil {
+		return nil, nil
+	}
+	{{- end}}
+	{{- if .Custom}}
+	data, err := json.Marshal(obj.{{.GoName}})
+	if err != nil {
+		return {{if .Nullable}}nil{{else}}""{{end}}, err
+	}
+	s := string(data)
+	{{- else}}
+	s := string(obj.{{.GoName}})
+	{{- end}}
+	return {{if .Nullable}}&s{{else}}s{{end}}, nil
+}
+{{- end}}
+
+// {{.TypeName}} returns {{.TypeName}}Resolver implementation.
+func (r *Resolver) {{.TypeName}}() {{.TypeName}}Resolver { return &{{.ResolverName}}{r} }
+{{- if .InputResolvers}}
+{{- range .Enums}}
+
+// {{.MethodName}} 校验并设置输入的{{.Comment}}
+{{- if .Set}}
+func (r *{{$.InputResolver}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}, data []string) error {
+	values := {{.TypeName}}FromStrings(data)
+	for _, v := range values {
+		if !v.Valid() {
+			return fmt.Errorf("无效的{{.Comment}}: %q", string(v))
+		}
+	}
+	obj.{{.GoName}} = values
+	return nil
+}
+{{- else}}
+func (r *{{$.InputResolver}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}, data *string) error {
+	if data == nil {
+		return nil
+	}
+	if v := {{.ElemName}}(*data); v != "" && !v.Valid() {
+		return fmt.Errorf("无效的{{.Comment}}: %q", *data)
+	}
+	obj.{{.GoName}} = {{.ElemName}}(*data)
+	return nil
+}
+{{- end}}
+{{- end}}
+{{- range .JSONFields}}
+
+// {{.MethodName}} 校验并设置输入的{{.Comment}}，空字符串表示未设置
+func (r *{{$.InputResolver}}) {{.MethodName}}(ctx context.Context, obj *{{$.ModelType}}, data *string) error {
+	if data == nil || *data == "" {
+		return nil
+	}
+	{{- if .Custom}}
+	if err := json.Unmarshal([]byte(*data), &obj.{{.GoName}}); err != nil {
+		return fmt.Errorf("无效的{{.Comment}}: %w", err)
+	}
+	{{- else}}
+	if !json.Valid([]byte(*data)) {
+		return fmt.Errorf("无效的{{.Comment}}: 不是合法的 JSON")
+	}
+	obj.{{.GoName}} = []byte(*data)
+	{{- end}}
+	return nil
+}
+{{- end}}
+
+// {{.TypeName}}Input returns {{.TypeName}}InputResolver implementation.
+func (r *Resolver) {{.TypeName}}Input() {{.TypeName}}InputResolver { return &{{.InputResolver}}{r} }
+{{- end}}
+
+type {{.ResolverName}} struct{ *Resolver }
+{{- if .InputResolvers}}
+type {{.InputResolver}} struct{ *Resolver }
+{{- end}}
+`
@@ -0,0 +1,836 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// GRPCPackage 生成的 gRPC 服务端代码包名
+const GRPCPackage = "grpcserver"
+
+// protoType Go 类型对应的 protobuf 类型及转换表达式，%s 为待转换的值
+type protoType struct {
+	Type      string
+	ToProto   string
+	FromProto string
+}
+
+// protoTypes Go 类型到 protobuf 类型的映射，时间使用 Timestamp，可空字段使用 wrappers
+var protoTypes = map[string]protoType{
+	"int":        {"int64", "int64(%s)", "int(%s)"},
+	"int64":      {"int64", "%s", "%s"},
+	"uint":       {"uint64", "uint64(%s)", "uint(%s)"},
+	"float64":    {"double", "%s", "%s"},
+	"string":     {"string", "%s", "%s"},
+	"bool":       {"bool", "%s", "%s"},
+	"[]byte":     {"bytes", "%s", "%s"},
+	"time.Time":  {"google.protobuf.Timestamp", "toTimestamp(%s)", "fromTimestamp(%s)"},
+	"*int":       {"google.protobuf.Int64Value", "toIntValue(%s)", "fromIntValue(%s)"},
+	"*int64":     {"google.protobuf.Int64Value", "toInt64Value(%s)", "fromInt64Value(%s)"},
+	"*float64":   {"google.protobuf.DoubleValue", "toDoubleValue(%s)", "fromDoubleValue(%s)"},
+	"*bool":      {"google.protobuf.BoolValue", "toBoolValue(%s)", "fromBoolValue(%s)"},
+	"*time.Time": {"google.protobuf.Timestamp", "toTimestampValue(%s)", "fromTimestampValue(%s)"},
+}
+
+// generateGRPC 生成 .proto 文件和委托给 Service 层的 gRPC 服务端实现
+func (g *Generator) generateGRPC(tables []TableInfo) error {
+	for _, dir := range []string{g.config.ProtoOutput, g.config.GRPCOutput} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建 gRPC 输出目录失败: %w", err)
+		}
+	}
+
+	// 生成公共 proto 与服务端基础文件
+	if err := g.generateProtoCommon(); err != nil {
+		return fmt.Errorf("生成公共 proto 失败: %w", err)
+	}
+	if err := g.generateGRPCBase(); err != nil {
+		return fmt.Errorf("生成 gRPC 基础文件失败: %w", err)
+	}
+
+	// 为每个表生成 proto 与服务端实现
+	var generated []TableInfo
+	for _, table := range tables {
+		if err := g.generateTableProto(table); err != nil {
+			log.Printf("生成表 %s 的 proto 失败: %v", table.Name, err)
+			continue
+		}
+		if err := g.generateTableGRPC(table); err != nil {
+			log.Printf("生成表 %s 的 gRPC 服务失败: %v", table.Name, err)
+			continue
+		}
+		generated = append(generated, table)
+		fmt.Printf("生成表 %s 的 gRPC 服务成功\n", table.Name)
+	}
+
+	// 生成汇总注册文件
+	if err := g.generateGRPCServer(generated); err != nil {
+		return fmt.Errorf("生成 gRPC 注册文件失败: %w", err)
+	}
+
+	return nil
+}
+
+// protoGoPackage 获取 proto 文件的 go_package 选项
+func (g *Generator) protoGoPackage() string {
+	return g.config.ProtoImportPath + ";" + path.Base(g.config.ProtoImportPath)
+}
+
+// generateProtoCommon 生成各表 proto 共用的消息定义
+func (g *Generator) generateProtoCommon() error {
+	tmpl := `syntax = "proto3";
+
+package {{.ProtoPackage}};
+
+option go_package = "{{.GoPackage}}";
+
+// Filter 列表过滤条件，op 可选 eq、in、gt、gte、lt、lte、like
+message Filter {
+  string column = 1;
+  string op = 2;
+  repeated string values = 3;
+}
+`
+
+	t, err := template.New("proto_common").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(g.config.ProtoOutput, "common.proto"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return t.Execute(file, map[string]interface{}{
+		"ProtoPackage": g.config.ProtoPackage,
+		"GoPackage":    g.protoGoPackage(),
+	})
+}
+
+// generateTableProto 生成表的 .proto 文件
+func (g *Generator) generateTableProto(table TableInfo) error {
+	tmpl := `syntax = "proto3";
+
+package {{.ProtoPackage}};
+
+option go_package = "{{.GoPackage}}";
+
+import "common.proto";
+{{- if not .ReadOnly}}
+import "google/protobuf/empty.proto";
+import "google/protobuf/field_mask.proto";
+{{- end}}
+{{- if .NeedTimestamp}}
+import "google/protobuf/timestamp.proto";
+{{- end}}
+{{- if .NeedWrappers}}
+import "google/protobuf/wrappers.proto";
+{{- end}}
+
+// {{.MessageName}} {{.Comment}}
+message {{.MessageName}} {
+  {{- range .Fields}}
+  {{- if .Comment}}
+  // {{.Comment}}
+  {{- end}}
+  {{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+}
+{{- if .PK}}
+
+message Get{{.MessageName}}Request {
+  {{- range .Keys}}
+  {{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+}
+{{- if not .ReadOnly}}
+
+message Update{{.MessageName}}Request {
+  {{.MessageName}} data = 1;
+  // 需要更新的字段，为空时更新除主键和创建时间外的全部字段
+  google.protobuf.FieldMask update_mask = 2;
+}
+
+message Delete{{.MessageName}}Request {
+  {{- range .Keys}}
+  {{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+}
+{{- end}}
+{{- end}}
+
+message List{{.PluralName}}Request {
+  int32 page = 1;
+  int32 page_size = 2;
+  // 排序字段，逗号分隔，- 前缀表示倒序，如 "-created_at,id"
+  string sort = 3;
+  repeated Filter filters = 4;
+  // 为 true 时不统计总数
+  bool skip_total = 5;
+}
+
+message List{{.PluralName}}Response {
+  repeated {{.MessageName}} items = 1;
+  int64 total = 2;
+  int32 page = 3;
+  int32 page_size = 4;
+}
+
+// {{.MessageName}}Service {{.Comment}}服务
+service {{.MessageName}}Service {
+  {{- if not .ReadOnly}}
+  rpc Create{{.MessageName}}({{.MessageName}}) returns ({{.MessageName}});
+  {{- end}}
+  {{- if .PK}}
+  rpc Get{{.MessageName}}(Get{{.MessageName}}Request) returns ({{.MessageName}});
+  {{- if not .ReadOnly}}
+  rpc Update{{.MessageName}}(Update{{.MessageName}}Request) returns ({{.MessageName}});
+  rpc Delete{{.MessageName}}(Delete{{.MessageName}}Request) returns (google.protobuf.Empty);
+  {{- end}}
+  {{- end}}
+  rpc List{{.PluralName}}(List{{.PluralName}}Request) returns (List{{.PluralName}}Response);
+}
+`
+
+	t, err := template.New("proto").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	fields := g.getProtoFields(table)
+	needTimestamp, needWrappers := false, false
+	for _, field := range fields {
+		switch {
+		case field["Type"] == "google.protobuf.Timestamp":
+			needTimestamp = true
+		case strings.HasPrefix(field["Type"].(string), "google.protobuf."):
+			needWrappers = true
+		}
+	}
+	keys := g.getProtoKeys(table)
+
+	data := map[string]interface{}{
+		"ProtoPackage":  g.config.ProtoPackage,
+		"GoPackage":     g.protoGoPackage(),
+		"MessageName":   g.modelName(table),
+		"PluralName":    g.pluralName(table),
+		"ReadOnly":      table.IsView,
+		"Comment":       table.Comment,
+		"Fields":        fields,
+		"Keys":          keys,
+		"PK":            len(keys) > 0,
+		"NeedTimestamp": needTimestamp,
+		"NeedWrappers":  needWrappers,
+	}
+
+	file, err := os.Create(filepath.Join(g.config.ProtoOutput, g.fileName(table)+".proto"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return t.Execute(file, data)
+}
+
+// generateGRPCBase 生成 gRPC 服务端基础文件：错误转换、列表参数与类型转换函数
+func (g *Generator) generateGRPCBase() error {
+	tmpl := `package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "{{.ProtoImportPath}}"
+	"{{.ServicePackage}}"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// toStatus 将 Service 错误转换为 gRPC 状态，无法识别的错误只记录日志，不向调用方暴露细节
+func toStatus(err error) error {
+	err = services.TranslateError(err)
+	var serviceErr services.ServiceError
+	if !errors.As(err, &serviceErr) {
+		log.Printf("gRPC 调用失败: %v", err)
+		return status.Error(codes.Internal, services.ErrInternal.Error())
+	}
+
+	code := codes.Internal
+	switch serviceErr.Code {
+	case 400, 422:
+		code = codes.InvalidArgument
+	case 403:
+		code = codes.PermissionDenied
+	case 404:
+		code = codes.NotFound
+	case 409:
+		code = codes.Aborted
+		if errors.Is(err, services.ErrDuplicateKey) {
+			code = codes.AlreadyExists
+		}
+	}
+	return status.Error(code, serviceErr.Message)
+}
+
+// listQuery 将列表请求参数转换为 services.ListQuery
+func listQuery(page, pageSize int32, sort string, filters []*pb.Filter, skipTotal bool) services.ListQuery {
+	q := services.ListQuery{
+		Page:      int(page),
+		PageSize:  int(pageSize),
+		SkipTotal: skipTotal,
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = 10
+	}
+	for _, f := range filters {
+		q.Filters = append(q.Filters, services.Filter{
+			Column: f.GetColumn(),
+			Op:     f.GetOp(),
+			Values: f.GetValues(),
+		})
+	}
+	for _, part := range strings.Split(sort, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			q.Sorts = append(q.Sorts, services.SortField{
+				Column: strings.TrimPrefix(part, "-"),
+				Desc:   strings.HasPrefix(part, "-"),
+			})
+		}
+	}
+	return q
+}
+
+// toTimestamp 零值时间转换为 nil
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp nil 转换为零值时间
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toTimestampValue(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestampValue(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toIntValue(v *int) *wrapperspb.Int64Value {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.Int64(int64(*v))
+}
+
+func fromIntValue(v *wrapperspb.Int64Value) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(v.GetValue())
+	return &n
+}
+
+func toInt64Value(v *int64) *wrapperspb.Int64Value {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.Int64(*v)
+}
+
+func fromInt64Value(v *wrapperspb.Int64Value) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := v.GetValue()
+	return &n
+}
+
+func toDoubleValue(v *float64) *wrapperspb.DoubleValue {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.Double(*v)
+}
+
+func fromDoubleValue(v *wrapperspb.DoubleValue) *float64 {
+	if v == nil {
+		return nil
+	}
+	n := v.GetValue()
+	return &n
+}
+
+func toBoolValue(v *bool) *wrapperspb.BoolValue {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.Bool(*v)
+}
+
+func fromBoolValue(v *wrapperspb.BoolValue) *bool {
+	if v == nil {
+		return nil
+	}
+	b := v.GetValue()
+	return &b
+}
+
+// toJSONString 将自定义类型的 JSON 字段编码为 JSON 字符串，nil 转换为空字符串
+func toJSONString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeJSON 将 JSON 字符串解码到 dst，空字符串设置为零值
+func decodeJSON[T any](s string, dst *T) error {
+	var v T
+	if s != "" {
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return err
+		}
+	}
+	*dst = v
+	return nil
+}
+
+// invalidJSON 返回 JSON 字段格式错误的 InvalidArgument 状态
+func invalidJSON(field string, err error) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf("字段 %s 不是合法的 JSON: %v", field, err))
+}
+`
+
+	t, err := template.New("grpc_base").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	return writeGoFile(filepath.Join(g.config.GRPCOutput, "base.go"), t, map[string]interface{}{
+		"Package":         GRPCPackage,
+		"ProtoImportPath": g.config.ProtoImportPath,
+		"ServicePackage":  g.config.ServiceImportPath,
+	})
+}
+
+// generateTableGRPC 生成表的 gRPC 服务端实现与模型转换函数
+func (g *Generator) generateTableGRPC(table TableInfo) error {
+	tmpl := `package {{.Package}}
+
+import (
+	"context"
+	{{- if and .PK (not .ReadOnly)}}
+	"fmt"
+	{{- end}}
+
+	"{{.ModelPackage}}"
+	pb "{{.ProtoImportPath}}"
+	"{{.ServicePackage}}"
+	{{- if and .PK (not .ReadOnly)}}
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	{{- end}}
+)
+
+// {{.ServerName}} {{.Comment}} gRPC 服务，委托给 services.{{.ServiceName}}
+type {{.ServerName}} struct {
+	pb.Unimplemented{{.MessageName}}ServiceServer
+	svc *services.{{.ServiceName}}
+}
+
+// New{{.ServerName}} 创建{{.Comment}} gRPC 服务
+func New{{.ServerName}}(svc *services.{{.ServiceName}}) *{{.ServerName}} {
+	return &{{.ServerName}}{svc: svc}
+}
+
+// {{.MessageName}}ToProto 将{{.Comment}}模型转换为 protobuf 消息
+func {{.MessageName}}ToProto(m *{{.ModelType}}) *pb.{{.MessageName}} {
+	if m == nil {
+		return nil
+	}
+	return &pb.{{.MessageName}}{
+		{{- range .Fields}}
+		{{.PbName}}: {{.ToProto}},
+		{{- end}}
+	}
+}
+
+// {{.MessageName}}FromProto 将 protobuf 消息转换为{{.Comment}}模型
+func {{.MessageName}}FromProto(p *pb.{{.MessageName}}) *{{.ModelType}} {
+	m := &{{.ModelType}}{}
+	if p == nil {
+		return m
+	}
+	{{- range .Fields}}
+	{{- if .Decode}}
+	_ = decodeJSON(p.{{.PbName}}, &m.{{.GoName}})
+	{{- else}}
+	m.{{.GoName}} = {{.FromProto}}
+	{{- end}}
+	{{- end}}
+	return m
+}
+{{- if not .ReadOnly}}
+
+// Create{{.MessageName}} 创建{{.Comment}}
+func (s *{{.ServerName}}) Create{{.MessageName}}(ctx context.Context, req *pb.{{.MessageName}}) (*pb.{{.MessageName}}, error) {
+	m := {{.MessageName}}FromProto(req)
+	{{- range .Fields}}
+	{{- if .Decode}}
+	if err := decodeJSON(req.{{.PbName}}, &m.{{.GoName}}); err != nil {
+		return nil, invalidJSON("{{.Name}}", err)
+	}
+	{{- end}}
+	{{- end}}
+	if err := s.svc.Create(ctx, m); err != nil {
+		return nil, toStatus(err)
+	}
+	return {{.MessageName}}ToProto(m), nil
+}
+{{- end}}
+{{- if .PK}}
+
+// Get{{.MessageName}} 获取{{.Comment}}
+func (s *{{.ServerName}}) Get{{.MessageName}}(ctx context.Context, req *pb.Get{{.MessageName}}Request) (*pb.{{.MessageName}}, error) {
+	m, err := s.svc.GetByID(ctx, {{.KeyArgs}})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return {{.MessageName}}ToProto(m), nil
+}
+{{- if not .ReadOnly}}
+
+// {{.LowerMessageName}}UpdatePaths 未指定 update_mask 时更新的字段
+var {{.LowerMessageName}}UpdatePaths = []string{
+	{{- range .UpdateFields}}
+	"{{.Name}}",
+	{{- end}}
+}
+
+// apply{{.MessageName}}Mask 按 update_mask 将 p 中的字段写入 dst
+func apply{{.MessageName}}Mask(dst *{{.ModelType}}, p *pb.{{.MessageName}}, paths []string) error {
+	if len(paths) == 0 {
+		paths = {{.LowerMessageName}}UpdatePaths
+	}
+	for _, path := range paths {
+		switch path {
+		{{- range .UpdateFields}}
+		case "{{.Name}}":
+			{{- if .Decode}}
+			if err := decodeJSON(p.{{.PbName}}, &dst.{{.GoName}}); err != nil {
+				return invalidJSON("{{.Name}}", err)
+			}
+			{{- else}}
+			dst.{{.GoName}} = {{.FromProto}}
+			{{- end}}
+		{{- end}}
+		default:
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("不支持更新的字段: %s", path))
+		}
+	}
+	{{- if .Version}}
+	// 乐观锁：使用调用方提交的版本号
+	dst.{{.Version.GoName}} = {{.Version.FromProto}}
+	{{- end}}
+	return nil
+}
+
+// Update{{.MessageName}} 按 update_mask 更新{{.Comment}}
+func (s *{{.ServerName}}) Update{{.MessageName}}(ctx context.Context, req *pb.Update{{.MessageName}}Request) (*pb.{{.MessageName}}, error) {
+	p := req.GetData()
+	if p == nil {
+		return nil, status.Error(codes.InvalidArgument, "缺少更新数据")
+	}
+	m, err := s.svc.GetByID(ctx, {{.DataKeyArgs}})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := apply{{.MessageName}}Mask(m, p, req.GetUpdateMask().GetPaths()); err != nil {
+		return nil, err
+	}
+	if err := s.svc.Update(ctx, m); err != nil {
+		return nil, toStatus(err)
+	}
+	return {{.MessageName}}ToProto(m), nil
+}
+
+// Delete{{.MessageName}} 删除{{.Comment}}
+func (s *{{.ServerName}}) Delete{{.MessageName}}(ctx context.Context, req *pb.Delete{{.MessageName}}Request) (*emptypb.Empty, error) {
+	if err := s.svc.Delete(ctx, {{.KeyArgs}}); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+{{- end}}
+{{- end}}
+
+// List{{.PluralName}} 分页获取{{.Comment}}列表
+func (s *{{.ServerName}}) List{{.PluralName}}(ctx context.Context, req *pb.List{{.PluralName}}Request) (*pb.List{{.PluralName}}Response, error) {
+	q := listQuery(req.GetPage(), req.GetPageSize(), req.GetSort(), req.GetFilters(), req.GetSkipTotal())
+	items, total, err := s.svc.List(ctx, q)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.List{{.PluralName}}Response{
+		Items:    make([]*pb.{{.MessageName}}, 0, len(items)),
+		Total:    total,
+		Page:     int32(q.Page),
+		PageSize: int32(q.PageSize),
+	}
+	for i := range items {
+		resp.Items = append(resp.Items, {{.MessageName}}ToProto(&items[i]))
+	}
+	return resp, nil
+}
+`
+
+	t, err := template.New("grpc").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	fields := g.getProtoFields(table)
+	keys := g.getProtoKeys(table)
+
+	// 可更新字段：排除主键、创建时间、版本字段与租户字段
+	version := g.getVersionColumn(table)
+	tenant := g.getTenantColumn(table)
+	var updateFields []map[string]interface{}
+	var versionField map[string]interface{}
+	for _, field := range fields {
+		name := field["DBName"].(string)
+		switch {
+		case version != nil && name == version["DBName"]:
+			versionField = field
+		case tenant != nil && name == tenant["DBName"]:
+		case contains(table.PrimaryKeys, name), strings.Contains(name, "created_at"):
+		default:
+			updateFields = append(updateFields, field)
+		}
+	}
+
+	var keyArgs, dataKeyArgs []string
+	for _, key := range keys {
+		keyArgs = append(keyArgs, fmt.Sprintf(key["FromProto"].(string), "req."+key["PbName"].(string)))
+		dataKeyArgs = append(dataKeyArgs, fmt.Sprintf(key["FromProto"].(string), "p."+key["PbName"].(string)))
+	}
+
+	messageName := g.modelName(table)
+	data := map[string]interface{}{
+		"Package":          GRPCPackage,
+		"ModelPackage":     g.config.ModelImportPath,
+		"ProtoImportPath":  g.config.ProtoImportPath,
+		"ServicePackage":   g.config.ServiceImportPath,
+		"MessageName":      messageName,
+		"PluralName":       g.pluralName(table),
+		"ReadOnly":         table.IsView,
+		"LowerMessageName": g.toLowerCamelCase(g.modelName(table)),
+		"ServerName":       messageName + "Server",
+		"ServiceName":      messageName + "Service",
+		"ModelType":        g.modelPackageName() + "." + messageName,
+		"Comment":          table.Comment,
+		"Fields":           fields,
+		"UpdateFields":     updateFields,
+		"Version":          versionField,
+		"PK":               len(keys) > 0,
+		"KeyArgs":          strings.Join(keyArgs, ", "),
+		"DataKeyArgs":      strings.Join(dataKeyArgs, ", "),
+	}
+
+	return writeGoFile(filepath.Join(g.config.GRPCOutput, g.fileName(table)+"_server.go"), t, data)
+}
+
+// generateGRPCServer 生成 server.go，RegisterAll 一次注册全部表的 gRPC 服务
+func (g *Generator) generateGRPCServer(tables []TableInfo) error {
+	tmpl := `package {{.Package}}
+
+import (
+	pb "{{.ProtoImportPath}}"
+	"{{.ServicePackage}}"
+	"google.golang.org/grpc"
+)
+
+// RegisterAll 将全部表的 gRPC 服务注册到 s，服务使用 repos 中的 Service
+func RegisterAll(s grpc.ServiceRegistrar, repos services.Repos) {
+	{{- range .Servers}}
+	pb.Register{{.MessageName}}ServiceServer(s, New{{.MessageName}}Server(repos.{{.RepoName}}))
+	{{- end}}
+}
+`
+
+	t, err := template.New("grpc_server").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	var servers []map[string]interface{}
+	for _, table := range tables {
+		servers = append(servers, map[string]interface{}{
+			"MessageName": g.modelName(table),
+			"RepoName":    g.pluralName(table),
+		})
+	}
+
+	return writeGoFile(filepath.Join(g.config.GRPCOutput, "server.go"), t, map[string]interface{}{
+		"Package":         GRPCPackage,
+		"ProtoImportPath": g.config.ProtoImportPath,
+		"ServicePackage":  g.config.ServiceImportPath,
+		"Servers":         servers,
+	})
+}
+
+// modelGoType 获取字段在模型中的实际 Go 类型，嵌入 BaseModel 时基础字段使用 BaseModel 的类型
+func (g *Generator) modelGoType(table TableInfo, col ColumnInfo) string {
+	if g.useBaseModel(table) {
+		switch col.Name {
+		case "id":
+			return "uint"
+		case "created_at", "updated_at":
+			return "time.Time"
+		}
+	}
+	return col.GoType
+}
+
+// getProtoFields 获取表的 protobuf 字段，不支持的类型与 BaseModel 的软删除字段被跳过；
+// Name 为 protobuf 字段名（也是 update_mask 中的路径），DBName 为列名
+func (g *Generator) getProtoFields(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range table.Columns {
+		if g.useBaseModel(table) && col.Name == "deleted_at" {
+			continue
+		}
+		pt, ok := protoTypes[g.modelGoType(table, col)]
+		if col.Enum != nil {
+			pt, ok = g.enumProtoType(col), true
+		}
+		if col.JSON != nil {
+			pt, ok = jsonProtoType(col), true
+		}
+		if !ok {
+			log.Printf("警告: 表 %s 的字段 %s 类型 %s 无法映射为 protobuf 类型，已跳过", table.Name, col.Name, col.GoType)
+			continue
+		}
+		pbName := protoGoName(g.protoName(col))
+		// 自定义 JSON 类型没有转换表达式，由 decodeJSON 解码
+		var fromProto string
+		if pt.FromProto != "" {
+			fromProto = fmt.Sprintf(pt.FromProto, "p."+pbName)
+		}
+		result = append(result, map[string]interface{}{
+			"Name":      g.protoName(col),
+			"DBName":    col.Name,
+			"Type":      pt.Type,
+			"Number":    len(result) + 1,
+			"Comment":   col.Comment,
+			"GoName":    g.fieldName(table, col.Name),
+			"PbName":    pbName,
+			"ToProto":   fmt.Sprintf(pt.ToProto, "m."+g.fieldName(table, col.Name)),
+			"FromProto": fromProto,
+			"Decode":    col.JSON != nil && col.JSON.Custom,
+		})
+	}
+	return result
+}
+
+// enumProtoType 枚举对应 string、集合对应 repeated string，转换时使用模型包中的枚举类型
+func (g *Generator) enumProtoType(col ColumnInfo) protoType {
+	if col.Enum.Set {
+		return protoType{"repeated string", "%s.Strings()", g.modelPackageName() + "." + col.Enum.TypeName + "FromStrings(%s)"}
+	}
+	return protoType{"string", "string(%s)", g.modelPackageName() + "." + col.Enum.TypeName + "(%s)"}
+}
+
+// jsonProtoType JSON 列对应 string：datatypes.JSON 直接转换，自定义类型通过 toJSONString 编码、decodeJSON 解码
+func jsonProtoType(col ColumnInfo) protoType {
+	if col.JSON.Custom {
+		return protoType{"string", "toJSONString(%s)", ""}
+	}
+	return protoType{"string", "string(%s)", "[]byte(%s)"}
+}
+
+// getProtoKeys 获取主键对应的 protobuf 字段，FromProto 中的 %s 为主键值所在的表达式
+func (g *Generator) getProtoKeys(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for i, key := range g.getPrimaryKeyFields(table) {
+		pt := protoTypes[key["GoType"].(string)]
+		col, _ := findColumn(table.Columns, key["DBName"].(string))
+		name := g.protoName(col)
+		result = append(result, map[string]interface{}{
+			"Name":      name,
+			"Type":      pt.Type,
+			"Number":    i + 1,
+			"PbName":    protoGoName(name),
+			"FromProto": pt.FromProto,
+		})
+	}
+	return result
+}
+
+// protoGoName 按 protoc-gen-go 的规则将 proto 字段名转换为 Go 字段名
+func protoGoName(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// 下划线后的小写字母转为大写
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
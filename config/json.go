@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// JSONInfo JSON 列的映射信息，由 resolveNames 计算
+type JSONInfo struct {
+	// Custom 是否映射为 json_types 配置的自定义类型（通过 GORM 的 serializer:json 读写），
+	// 否则为 datatypes.JSON
+	Custom bool
+	// Import 自定义类型所在包的导入路径，模型包中的类型与内置类型为空
+	Import string
+	// Alias 导入自定义类型所在包时使用的别名，包名与导入路径最后一段相同时为空
+	Alias string
+}
+
+// isJSONColumn 判断是否为 JSON 列
+func isJSONColumn(col ColumnInfo) bool {
+	return strings.EqualFold(col.Type, "json")
+}
+
+// versionSuffix 导入路径中的主版本后缀：github.com/acme/lib/v2 的 /v2 与 gopkg.in/yaml.v3 的 .v3
+var versionSuffix = regexp.MustCompile(`(^|[/.])v[0-9]+$`)
+
+// parseJSONType 解析 json_types 中配置的类型，返回模型中的字段类型、需要导入的包及导入别名。
+// 格式为 [别名=][导入路径.]类型名，可带 []、map[string] 或 * 前缀，如 example.com/app/types.Meta、
+// []example.com/app/types.Tag、toml=github.com/pelletier/go-toml/v2.LocalDate、map[string]string；
+// 不带导入路径的大写类型名为模型包中的类型。未指定别名时按导入路径推断包名（去除主版本后缀，
+// - 与 . 等字符不能出现在标识符中，予以去除），推断的包名与导入路径最后一段不同时以其为别名导入。
+// 不带前缀的命名类型（通常为结构体）使用指针，未设置时为 nil，与其他可选字段一样在更新接口中跳过
+func parseJSONType(spec string) (goType, importPath, alias string, ok bool) {
+	spec = strings.TrimSpace(spec)
+	var prefix string
+	for {
+		var n int
+		switch {
+		case strings.HasPrefix(spec, "*"):
+			n = 1
+		case strings.HasPrefix(spec, "[]"):
+			n = 2
+		case strings.HasPrefix(spec, "map[string]"):
+			n = len("map[string]")
+		}
+		if n == 0 {
+			break
+		}
+		prefix, spec = prefix+spec[:n], spec[n:]
+	}
+	if i := strings.Index(spec, "="); i >= 0 {
+		alias, spec = spec[:i], spec[i+1:]
+		if !isIdent(alias) || !strings.Contains(spec, ".") {
+			return "", "", "", false
+		}
+	}
+	if spec == "" {
+		return "", "", "", false
+	}
+
+	name := spec
+	if i := strings.LastIndex(spec, "."); i >= 0 {
+		importPath, name = spec[:i], spec[i+1:]
+		if importPath == "" || name == "" {
+			return "", "", "", false
+		}
+		qualifier := alias
+		if qualifier == "" {
+			qualifier = importPackageName(importPath)
+		}
+		if qualifier == path.Base(importPath) {
+			alias = ""
+		} else {
+			alias = qualifier
+		}
+		name = qualifier + "." + name
+	}
+	if prefix == "" && (importPath != "" || unicode.IsUpper([]rune(name)[0])) {
+		prefix = "*"
+	}
+	return prefix + name, importPath, alias, true
+}
+
+// importPackageName 按导入路径推断包名：去除主版本后缀后取最后一段，并去除不能出现在标识符中的字符，
+// 如 gopkg.in/yaml.v3 → yaml、github.com/acme/lib/v2 → lib、github.com/acme/go-json → gojson
+func importPackageName(importPath string) string {
+	base := path.Base(importPath)
+	if trimmed := versionSuffix.ReplaceAllString(base, ""); trimmed != "" {
+		base = trimmed
+	} else if dir := path.Dir(importPath); dir != "." && dir != "/" {
+		base = path.Base(dir)
+	}
+	var b strings.Builder
+	for _, r := range base {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "pkg" + name
+	}
+	return name
+}
+
+// isIdent 判断是否为合法的 Go 标识符
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != "" && !goKeywords[s]
+}
+
+// jsonImports 获取 JSON 字段类型需要导入的包：datatypes.JSON 与自定义类型所在的包，按导入路径排序，
+// 返回带引号的导入声明，需要别名时以别名开头
+func jsonImports(columns []ColumnInfo) []string {
+	seen := make(map[string]string)
+	for _, col := range columns {
+		switch {
+		case col.JSON == nil:
+		case !col.JSON.Custom:
+			seen["gorm.io/datatypes"] = ""
+		case col.JSON.Import != "":
+			seen[col.JSON.Import] = col.JSON.Alias
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for importPath := range seen {
+		paths = append(paths, importPath)
+	}
+	sort.Strings(paths)
+	imports := make([]string, len(paths))
+	for i, importPath := range paths {
+		imports[i] = strconv.Quote(importPath)
+		if alias := seen[importPath]; alias != "" {
+			imports[i] = alias + " " + imports[i]
+		}
+	}
+	return imports
+}
+
+// resolveJSONTypes 为表的 JSON 列设置映射信息：json_types 按 表名.列名 配置的列使用自定义类型，
+// 其他 JSON 列使用 datatypes.JSON
+func (g *Generator) resolveJSONTypes(table *TableInfo) {
+	keys := make([]string, 0, len(g.config.JSONTypes))
+	for key := range g.config.JSONTypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		tableName, column, found := strings.Cut(key, ".")
+		if !found || tableName != table.Name {
+			continue
+		}
+		col, ok := findColumn(table.Columns, column)
+		if !ok {
+			log.Printf("警告: json_types 配置的列 %s 不存在，已忽略", key)
+		} else if !isJSONColumn(col) {
+			log.Printf("警告: json_types 配置的列 %s 类型为 %s，不是 JSON 列，已忽略", key, col.Type)
+		}
+	}
+
+	for j := range table.Columns {
+		col := &table.Columns[j]
+		if !isJSONColumn(*col) {
+			continue
+		}
+		col.JSON = &JSONInfo{}
+		if spec, ok := g.config.JSONTypes[table.Name+"."+col.Name]; ok {
+			goType, importPath, alias, valid := parseJSONType(spec)
+			if !valid {
+				log.Printf("警告: 表 %s 的列 %s 配置的类型 %q 无效，使用 datatypes.JSON", table.Name, col.Name, spec)
+			} else {
+				col.JSON = &JSONInfo{Custom: true, Import: importPath, Alias: alias}
+				col.GoType = goType
+			}
+		}
+		col.GoTag = g.generateGoTag(*col)
+	}
+}
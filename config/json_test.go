@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONType(t *testing.T) {
+	tests := []struct {
+		spec       string
+		goType     string
+		importPath string
+		alias      string
+		ok         bool
+	}{
+		{"example.com/app/types.Meta", "*types.Meta", "example.com/app/types", "", true},
+		{"*example.com/app/types.Meta", "*types.Meta", "example.com/app/types", "", true},
+		{"[]example.com/app/types.Tag", "[]types.Tag", "example.com/app/types", "", true},
+		{"map[string]string", "map[string]string", "", "", true},
+		{"[]map[string]interface{}", "[]map[string]interface{}", "", "", true},
+		{"ArticleExtra", "*ArticleExtra", "", "", true},
+		{"gopkg.in/yaml.v3.Node", "*yaml.Node", "gopkg.in/yaml.v3", "yaml", true},
+		{"github.com/acme/lib/v2.Meta", "*lib.Meta", "github.com/acme/lib/v2", "lib", true},
+		{"[]github.com/acme/go-json.Tag", "[]gojson.Tag", "github.com/acme/go-json", "gojson", true},
+		{"[]toml=github.com/pelletier/go-toml/v2.LocalDate", "[]toml.LocalDate", "github.com/pelletier/go-toml/v2", "toml", true},
+		{"types=example.com/app/types.Meta", "*types.Meta", "example.com/app/types", "", true},
+		{"[]", "", "", "", false},
+		{"example.com/app/types.", "", "", "", false},
+		{"go-json=github.com/acme/go-json.Tag", "", "", "", false},
+		{"meta=Meta", "", "", "", false},
+	}
+	for _, tt := range tests {
+		goType, importPath, alias, ok := parseJSONType(tt.spec)
+		if goType != tt.goType || importPath != tt.importPath || alias != tt.alias || ok != tt.ok {
+			t.Errorf("parseJSONType(%q) = %q, %q, %q, %v, 期望 %q, %q, %q, %v", tt.spec, goType, importPath, alias, ok, tt.goType, tt.importPath, tt.alias, tt.ok)
+		}
+	}
+}
+
+func TestResolveJSONTypes(t *testing.T) {
+	g := NewGenerator(&Config{JSONTypes: map[string]string{
+		"articles.meta":  "example.com/app/types.Meta",
+		"articles.title": "example.com/app/types.Title",
+		"articles.bad":   "[]",
+	}})
+	table := TableInfo{Name: "articles", Columns: []ColumnInfo{
+		testColumn(g, "title", "varchar", false, false, false, ""),
+		testColumn(g, "meta", "json", false, false, false, ""),
+		testColumn(g, "extra", "json", true, false, false, ""),
+		testColumn(g, "bad", "json", false, false, false, ""),
+	}}
+	g.resolveJSONTypes(&table)
+
+	if table.Columns[0].JSON != nil || table.Columns[0].GoType != "string" {
+		t.Errorf("非 JSON 列不应映射: %+v", table.Columns[0])
+	}
+	meta := table.Columns[1]
+	if meta.JSON == nil || !meta.JSON.Custom || meta.GoType != "*types.Meta" || !strings.Contains(meta.GoTag, "serializer:json") {
+		t.Errorf("meta 映射错误: GoType=%s JSON=%+v GoTag=%s", meta.GoType, meta.JSON, meta.GoTag)
+	}
+	for _, col := range table.Columns[2:] {
+		if col.JSON == nil || col.JSON.Custom || col.GoType != "datatypes.JSON" {
+			t.Errorf("%s 应使用 datatypes.JSON: GoType=%s JSON=%+v", col.Name, col.GoType, col.JSON)
+		}
+	}
+
+	want := []string{`"example.com/app/types"`, `"gorm.io/datatypes"`}
+	if got := jsonImports(table.Columns); !reflect.DeepEqual(got, want) {
+		t.Errorf("jsonImports = %v, 期望 %v", got, want)
+	}
+}
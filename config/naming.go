@@ -0,0 +1,357 @@
+package generator
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/inflection"
+)
+
+// commonInitialisms golint 的缩写词列表，生成 Go 名称时整体大写，如 UserID、APIURL、HTTPStatus
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH",
+	"TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML",
+	"XMPP", "XSRF", "XSS",
+}
+
+// goKeywords Go 关键字，不能直接用作变量名
+var goKeywords = map[string]bool{
+	"break": true, "default": true, "func": true, "interface": true, "select": true,
+	"case": true, "defer": true, "go": true, "map": true, "struct": true,
+	"chan": true, "else": true, "goto": true, "package": true, "switch": true,
+	"const": true, "fallthrough": true, "if": true, "range": true, "type": true,
+	"continue": true, "for": true, "import": true, "return": true, "var": true,
+}
+
+// buildInitialisms 合并内置缩写词与配置的缩写词，统一为大写
+func buildInitialisms(extra []string) map[string]bool {
+	result := make(map[string]bool, len(commonInitialisms)+len(extra))
+	for _, word := range commonInitialisms {
+		result[word] = true
+	}
+	for _, word := range extra {
+		if word = strings.TrimSpace(word); word != "" {
+			result[strings.ToUpper(word)] = true
+		}
+	}
+	return result
+}
+
+// splitWords 将名称拆分为单词：非字母数字字符为分隔符，并在大小写边界处拆分，
+// 如 user_name、userName、HTTPStatus 分别拆为 user/name、user/Name、HTTP/Status；
+// 缩写词的复数形式（IDs、URLs）不拆分
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			var next rune
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			plural := next == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2]))
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && unicode.IsLower(next) && !plural) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// formatWord 将单词转换为首字母大写，缩写词及其复数形式整体大写（ID、IDs）
+func (g *Generator) formatWord(word string) string {
+	lower := strings.ToLower(word)
+	upper := strings.ToUpper(lower)
+	if g.initialisms[upper] {
+		return upper
+	}
+	if len(lower) > 2 && strings.HasSuffix(lower, "s") && g.initialisms[upper[:len(upper)-1]] {
+		return upper[:len(upper)-1] + "s"
+	}
+	runes := []rune(lower)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// toCamelCase 转换为导出的驼峰命名，缩写词整体大写；结果不以大写字母开头时（数字、中文等）加 X 前缀
+func (g *Generator) toCamelCase(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		b.WriteString(g.formatWord(word))
+	}
+	result := b.String()
+	if r := []rune(result); len(r) == 0 || !unicode.IsUpper(r[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// toLowerCamelCase 转换为小驼峰命名，首个单词整体小写（id、apiKey）；以数字开头时加 x 前缀
+func (g *Generator) toLowerCamelCase(s string) string {
+	var b strings.Builder
+	for i, word := range splitWords(s) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+		} else {
+			b.WriteString(g.formatWord(word))
+		}
+	}
+	result := b.String()
+	if r := []rune(result); len(r) == 0 || unicode.IsDigit(r[0]) {
+		result = "x" + result
+	}
+	return result
+}
+
+// lowerCamel 转换为 GraphQL、TypeScript 使用的小驼峰命名，不套用 Go 缩写词规则，如 userId、apiKeys
+func lowerCamel(s string) string {
+	var b strings.Builder
+	for i, word := range splitWords(s) {
+		word = strings.ToLower(word)
+		if i > 0 {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// joinWords 将拆分后的单词转为小写并以 sep 连接；没有可拆分的单词时返回原名称的小写形式
+func joinWords(s, sep string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return strings.ToLower(s)
+	}
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
+
+// toSnakeCase 转换为蛇形命名，按单词拆分，如 userName → user_name、HTTPCode → http_code、user_id 不变
+func toSnakeCase(s string) string {
+	return joinWords(s, "_")
+}
+
+// asciiIdent 转换为只含 ASCII 字母、数字与下划线的标识符，供 protobuf、GraphQL 等不接受非 ASCII 名称的场景使用：
+// 去除其他字符及首尾下划线，结果为空或以数字开头时加 x 前缀，如 1st_place → x1st_place、用户名 → x
+func asciiIdent(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	result := strings.Trim(b.String(), "_")
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "x" + result
+	}
+	return result
+}
+
+// toKebabCase 转换为短横线命名，如 orderItems → order-items
+func toKebabCase(s string) string {
+	return joinWords(s, "-")
+}
+
+// toJSONCase 按 JSONCase 配置转换列名为 JSON 字段名
+func (g *Generator) toJSONCase(name string) string {
+	switch g.config.JSONCase {
+	case JSONCaseCamel:
+		if camel := lowerCamel(name); camel != "" {
+			return camel
+		}
+		return name
+	case JSONCaseOriginal:
+		return name
+	default:
+		return toSnakeCase(name)
+	}
+}
+
+// toVarName 转换为 Go 变量名，与关键字相同时追加下划线，如 type_
+func (g *Generator) toVarName(s string) string {
+	name := g.toLowerCamelCase(s)
+	if goKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// baseName 去除配置的表名前缀后的表名，按配置顺序匹配第一个前缀；去除后为空时保留原表名
+func (g *Generator) baseName(table TableInfo) string {
+	for _, prefix := range g.config.TablePrefixes {
+		if prefix != "" && strings.HasPrefix(table.Name, prefix) && len(table.Name) > len(prefix) {
+			return table.Name[len(prefix):]
+		}
+	}
+	return table.Name
+}
+
+// defaultModelName 默认的模型结构体名：去除前缀后的表名转为单数，如 t_users → User
+func (g *Generator) defaultModelName(table TableInfo) string {
+	if name := g.config.TableNaming[table.Name].Struct; name != "" {
+		return g.toCamelCase(name)
+	}
+	return g.toCamelCase(inflection.Singular(g.baseName(table)))
+}
+
+// defaultFileName 默认的文件名（不含后缀）：去除前缀后的表名，如 t_users → users
+func (g *Generator) defaultFileName(table TableInfo) string {
+	if name := g.config.TableNaming[table.Name].File; name != "" {
+		return name
+	}
+	return toSnakeCase(g.baseName(table))
+}
+
+// defaultRoutePath 默认的路由路径（不含前导 /）：去除前缀后的表名转为复数，如 t_user → users；
+// RouteCase 为 kebab 时以短横线连接，如 order_item → order-items
+func (g *Generator) defaultRoutePath(table TableInfo) string {
+	if name := g.config.TableNaming[table.Name].Route; name != "" {
+		return strings.Trim(name, "/")
+	}
+	path := inflection.Plural(inflection.Singular(toSnakeCase(g.baseName(table))))
+	if g.config.RouteCase == RouteCaseKebab {
+		path = toKebabCase(path)
+	}
+	return path
+}
+
+// pluralName 模型结构体名的复数形式，用于列表方法名与 Repos 字段名，如 ListUsers、Repos.Users；
+// 冲突时追加的数字后缀保留在末尾，如 User2 → Users2
+func (g *Generator) pluralName(table TableInfo) string {
+	name := g.modelName(table)
+	stem := strings.TrimRightFunc(name, unicode.IsDigit)
+	if stem == "" {
+		return name
+	}
+	return inflection.Plural(stem) + name[len(stem):]
+}
+
+// pluralVarName 模型变量名的复数形式；单复数相同（如 News）时追加 List 以免与单数变量重名
+func (g *Generator) pluralVarName(table TableInfo) string {
+	name := g.toLowerCamelCase(g.pluralName(table))
+	if name == g.toLowerCamelCase(g.modelName(table)) {
+		name += "List"
+	}
+	return name
+}
+
+// resolveNames 计算表的结构体名、文件名、路由路径与列的字段名、JSON 字段名、枚举类型名与 JSON 列的映射类型。
+// 名称冲突时按表、列的出现顺序保留先出现者，后出现者追加从 2 开始的数字后缀并输出警告
+func (g *Generator) resolveNames(tables []TableInfo) []TableInfo {
+	result := make([]TableInfo, len(tables))
+	structs := map[string]string{"BaseModel": "BaseModel"}
+	// 公共文件占用的文件名：models/base.go、pb/common.proto、graph/schema/schema.graphqls
+	files := map[string]string{"base": "公共文件 base.go", "common": "公共文件 common.proto", "schema": "公共文件 schema.graphqls"}
+	routes := map[string]string{}
+	for i, table := range tables {
+		name := g.defaultModelName(table)
+		table.GoName = uniqueName(name, structs, "表 "+table.Name)
+		if table.GoName != name {
+			log.Printf("警告: 表 %s 的结构体名 %s 已被 %s 占用，改用 %s", table.Name, name, structs[name], table.GoName)
+		}
+		name = g.defaultFileName(table)
+		table.FileName = uniqueName(name, files, "表 "+table.Name)
+		if table.FileName != name {
+			log.Printf("警告: 表 %s 的文件名 %s 已被 %s 占用，改用 %s", table.Name, name, files[name], table.FileName)
+		}
+		name = g.defaultRoutePath(table)
+		table.RoutePath = uniqueName(name, routes, "表 "+table.Name)
+		if table.RoutePath != name {
+			log.Printf("警告: 表 %s 的路由路径 %s 已被 %s 占用，改用 %s", table.Name, name, routes[name], table.RoutePath)
+		}
+
+		// TableName 方法与嵌入的 BaseModel 占用的名称；BaseModel 的列先于其他列分配
+		fields := map[string]string{"TableName": "TableName 方法"}
+		columns := make([]ColumnInfo, len(table.Columns))
+		copy(columns, table.Columns)
+		var base, rest []int
+		embed := g.useBaseModel(table)
+		if embed {
+			fields["BaseModel"] = "嵌入的 BaseModel"
+		}
+		for j, col := range columns {
+			if embed && contains(baseModelColumns, col.Name) {
+				base = append(base, j)
+			} else {
+				rest = append(rest, j)
+			}
+		}
+		jsonNames, protoNames, graphQLNames := map[string]string{}, map[string]string{}, map[string]string{}
+		for _, j := range append(base, rest...) {
+			col := &columns[j]
+			name := g.toCamelCase(col.Name)
+			col.GoName = uniqueName(name, fields, "列 "+col.Name)
+			if col.GoName != name {
+				log.Printf("警告: 表 %s 的列 %s 的字段名 %s 已被 %s 占用，改用 %s", table.Name, col.Name, name, fields[name], col.GoName)
+			}
+
+			jsonName := g.toJSONCase(col.Name)
+			col.JSONName = uniqueName(jsonName, jsonNames, "列 "+col.Name)
+			if col.JSONName != jsonName {
+				log.Printf("警告: 表 %s 的列 %s 的 JSON 字段名 %s 已被 %s 占用，改用 %s", table.Name, col.Name, jsonName, jsonNames[jsonName], col.JSONName)
+				col.GoTag = g.generateGoTag(*col)
+			}
+
+			// protoc 按 protobuf 字段名生成 JSON 名与 Go 字段名，需单独去重，如 user_id 与 userId 都会生成 userId；
+			// protobuf 与 GraphQL 字段名只能使用 ASCII 字符，不同的中文列名可能转换为相同的名称
+			protoName := asciiIdent(toSnakeCase(col.Name))
+			col.ProtoName = uniqueName(protoName, protoNames, "列 "+col.Name)
+			if col.ProtoName != protoName {
+				log.Printf("警告: 表 %s 的列 %s 的 protobuf 字段名 %s 已被 %s 占用，改用 %s", table.Name, col.Name, protoName, protoNames[protoName], col.ProtoName)
+			}
+
+			graphQLName := asciiIdent(lowerCamel(col.GoName))
+			col.GraphQLName = uniqueName(graphQLName, graphQLNames, "列 "+col.Name)
+			if col.GraphQLName != graphQLName {
+				log.Printf("警告: 表 %s 的列 %s 的 GraphQL 字段名 %s 已被 %s 占用，改用 %s", table.Name, col.Name, graphQLName, graphQLNames[graphQLName], col.GraphQLName)
+			}
+		}
+		table.Columns = columns
+		result[i] = table
+	}
+
+	// 枚举类型名在全部结构体名确定后分配，与结构体及分表模型生成的标识符同在模型包中
+	for _, table := range result {
+		if len(table.Shards) > 0 {
+			for _, suffix := range []string{"Shards", "ShardFunc", "Scope"} {
+				structs[table.GoName+suffix] = "表 " + table.Name + " 的 " + table.GoName + suffix
+			}
+		}
+	}
+	for i := range result {
+		g.resolveEnums(&result[i], structs)
+		g.resolveJSONTypes(&result[i])
+	}
+	return result
+}
+
+// uniqueName 返回 used 中未占用的名称并登记来源 owner，冲突时追加从 2 开始的数字后缀
+func uniqueName(name string, used map[string]string, owner string) string {
+	candidate := name
+	for n := 2; used[candidate] != ""; n++ {
+		candidate = name + strconv.Itoa(n)
+	}
+	used[candidate] = owner
+	return candidate
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToCamelCase(t *testing.T) {
+	g := NewGenerator(&Config{Initialisms: []string{"sku"}})
+	tests := []struct {
+		in, want string
+	}{
+		{"user_id", "UserID"},
+		{"api_url", "APIURL"},
+		{"http_status", "HTTPStatus"},
+		{"userId", "UserID"},
+		{"HTTPStatus", "HTTPStatus"},
+		{"role_ids", "RoleIDs"},
+		{"md5_hash", "Md5Hash"},
+		{"sku_code", "SKUCode"},
+		{"order-items", "OrderItems"},
+		{"2fa_secret", "X2faSecret"},
+		{"用户名", "X用户名"},
+		{"type", "Type"},
+		{"", "X"},
+	}
+	for _, tt := range tests {
+		if got := g.toCamelCase(tt.in); got != tt.want {
+			t.Errorf("toCamelCase(%q) = %q, 期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToLowerCamelCase(t *testing.T) {
+	g := NewGenerator(&Config{})
+	tests := []struct {
+		in, want string
+	}{
+		{"id", "id"},
+		{"user_id", "userID"},
+		{"api_keys", "apiKeys"},
+		{"APIKeys", "apiKeys"},
+		{"IDs", "ids"},
+		{"2fa", "x2fa"},
+	}
+	for _, tt := range tests {
+		if got := g.toLowerCamelCase(tt.in); got != tt.want {
+			t.Errorf("toLowerCamelCase(%q) = %q, 期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToVarName(t *testing.T) {
+	g := NewGenerator(&Config{})
+	for in, want := range map[string]string{"type": "type_", "func": "func_", "Range": "range_", "user_id": "userID"} {
+		if got := g.toVarName(in); got != want {
+			t.Errorf("toVarName(%q) = %q, 期望 %q", in, got, want)
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	for in, want := range map[string]string{"user_id": "userId", "APIKeys": "apiKeys", "UserID2": "userId2", "X2faSecret": "x2faSecret"} {
+		if got := lowerCamel(in); got != want {
+			t.Errorf("lowerCamel(%q) = %q, 期望 %q", in, got, want)
+		}
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := []struct {
+		in, snake, kebab string
+	}{
+		{"user_id", "user_id", "user-id"},
+		{"userName", "user_name", "user-name"},
+		{"HTTPCode", "http_code", "http-code"},
+		{"UserID", "user_id", "user-id"},
+		{"roleIDs", "role_ids", "role-ids"},
+		{"user__id", "user_id", "user-id"},
+		{"order-items", "order_items", "order-items"},
+		{"Md5Hash", "md5_hash", "md5-hash"},
+		{"___", "___", "___"},
+	}
+	for _, tt := range tests {
+		if got := toSnakeCase(tt.in); got != tt.snake {
+			t.Errorf("toSnakeCase(%q) = %q, 期望 %q", tt.in, got, tt.snake)
+		}
+		if got := toKebabCase(tt.in); got != tt.kebab {
+			t.Errorf("toKebabCase(%q) = %q, 期望 %q", tt.in, got, tt.kebab)
+		}
+	}
+}
+
+func TestToJSONCase(t *testing.T) {
+	tests := []struct {
+		jsonCase, in, want string
+	}{
+		{"", "HTTPCode", "http_code"},
+		{JSONCaseSnake, "userName", "user_name"},
+		{JSONCaseCamel, "user_id", "userId"},
+		{JSONCaseCamel, "HTTPCode", "httpCode"},
+		{JSONCaseOriginal, "HTTPCode", "HTTPCode"},
+		{"pascal", "user_id", "user_id"},
+	}
+	for _, tt := range tests {
+		g := NewGenerator(&Config{JSONCase: tt.jsonCase})
+		if got := g.toJSONCase(tt.in); got != tt.want {
+			t.Errorf("json_case=%s: toJSONCase(%q) = %q, 期望 %q", tt.jsonCase, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResolveNames(t *testing.T) {
+	g := NewGenerator(&Config{})
+	tables := g.resolveNames([]TableInfo{
+		{Name: "order_items", Columns: []ColumnInfo{
+			{Name: "user_id"}, {Name: "userId"}, {Name: "user__id"}, {Name: "table_name"},
+		}},
+		{Name: "orderItems"},
+		{Name: "base_model"},
+	})
+
+	wantTables := []string{"OrderItem", "OrderItem2", "BaseModel2"}
+	for i, want := range wantTables {
+		if got := tables[i].GoName; got != want {
+			t.Errorf("表 %s 的结构体名 = %q, 期望 %q", tables[i].Name, got, want)
+		}
+	}
+
+	wantFields := []struct{ goName, jsonName, protoName string }{
+		{"UserID", "user_id", "user_id"},
+		{"UserID2", "user_id2", "user_id2"},
+		{"UserID3", "user_id3", "user_id3"},
+		{"TableName2", "table_name", "table_name"},
+	}
+	for i, want := range wantFields {
+		col := tables[0].Columns[i]
+		if col.GoName != want.goName || col.JSONName != want.jsonName || col.ProtoName != want.protoName {
+			t.Errorf("列 %s 的名称 = %q/%q/%q, 期望 %q/%q/%q", col.Name, col.GoName, col.JSONName, col.ProtoName, want.goName, want.jsonName, want.protoName)
+		}
+	}
+
+	// protobuf 字段名不受 json_case 影响，始终为去重后的蛇形命名
+	g = NewGenerator(&Config{JSONCase: JSONCaseCamel})
+	tables = g.resolveNames([]TableInfo{{Name: "api_keys", Columns: []ColumnInfo{{Name: "user_id"}, {Name: "userId"}}}})
+	if got := []string{tables[0].Columns[0].ProtoName, tables[0].Columns[1].ProtoName}; !reflect.DeepEqual(got, []string{"user_id", "user_id2"}) {
+		t.Errorf("protobuf 字段名 = %v, 期望 [user_id user_id2]", got)
+	}
+
+	// protobuf 与 GraphQL 字段名只使用 ASCII 字符，数字开头时加 x 前缀，转换后重名时去重
+	tables = g.resolveNames([]TableInfo{{Name: "ranks", Columns: []ColumnInfo{{Name: "1st_place"}, {Name: "用户名"}, {Name: "备注"}}}})
+	wantNames := []struct{ goName, protoName, graphQLName string }{
+		{"X1stPlace", "x1st_place", "x1stPlace"},
+		{"X用户名", "x", "x"},
+		{"X备注", "x2", "x2"},
+	}
+	for i, want := range wantNames {
+		col := tables[0].Columns[i]
+		if col.GoName != want.goName || col.ProtoName != want.protoName || col.GraphQLName != want.graphQLName {
+			t.Errorf("列 %s 的名称 = %q/%q/%q, 期望 %q/%q/%q", col.Name, col.GoName, col.ProtoName, col.GraphQLName, want.goName, want.protoName, want.graphQLName)
+		}
+	}
+}
+
+func TestResolveTableNames(t *testing.T) {
+	g := NewGenerator(&Config{
+		TablePrefixes: []string{"t_", "tb_"},
+		TableNaming:   map[string]TableNaming{"tb_members": {Struct: "account", File: "account", Route: "/accounts/"}},
+	})
+	tables := g.resolveNames([]TableInfo{
+		{Name: "t_users"}, {Name: "tb_members"}, {Name: "category"}, {Name: "news"}, {Name: "users"}, {Name: "t_"},
+	})
+
+	want := []struct{ goName, plural, file, route string }{
+		{"User", "Users", "users", "users"},
+		{"Account", "Accounts", "account", "accounts"},
+		{"Category", "Categories", "category", "categories"},
+		{"News", "News", "news", "news"},
+		{"User2", "Users2", "users2", "users2"},
+		{"T", "TS", "t", "ts"},
+	}
+	for i, w := range want {
+		table := tables[i]
+		if table.GoName != w.goName || g.pluralName(table) != w.plural || table.FileName != w.file || table.RoutePath != w.route {
+			t.Errorf("表 %s 的名称 = %s/%s/%s/%s, 期望 %s/%s/%s/%s", table.Name,
+				table.GoName, g.pluralName(table), table.FileName, table.RoutePath, w.goName, w.plural, w.file, w.route)
+		}
+	}
+	if got := g.pluralVarName(tables[3]); got != "newsList" {
+		t.Errorf("pluralVarName(news) = %q, 期望 newsList", got)
+	}
+}
+
+func TestRouteCaseKebab(t *testing.T) {
+	g := NewGenerator(&Config{RouteCase: RouteCaseKebab})
+	tables := g.resolveNames([]TableInfo{{Name: "order_items"}, {Name: "userRole"}})
+	for i, want := range []string{"order-items", "user-roles"} {
+		if got := tables[i].RoutePath; got != want {
+			t.Errorf("表 %s 的路由路径 = %q, 期望 %q", tables[i].Name, got, want)
+		}
+	}
+	if got := tables[1].FileName; got != "user_role" {
+		t.Errorf("表 userRole 的文件名 = %q, 期望 user_role", got)
+	}
+}
@@ -22,26 +22,317 @@ func (g *Generator) generateRouters(tables []TableInfo) error {
 	}
 
 	// 为每个表生成 Router
+	var generated []TableInfo
 	for _, table := range tables {
 		if err := g.generateTableRouter(table); err != nil {
 			log.Printf("生成表 %s 的 Router 失败: %v", table.Name, err)
 			continue
 		}
+		generated = append(generated, table)
 		fmt.Printf("生成表 %s 的 Router 成功\n", table.Name)
+
+		if g.config.GenerateTests {
+			if err := g.generateTableRouterTest(table); err != nil {
+				log.Printf("生成表 %s 的 Router 测试失败: %v", table.Name, err)
+			}
+		}
+	}
+
+	// 生成汇总路由注册文件
+	if err := g.generateRoutes(generated); err != nil {
+		return fmt.Errorf("生成路由注册文件失败: %w", err)
+	}
+
+	// 生成 Router 测试公共文件
+	if g.config.GenerateTests {
+		if err := g.generateRouterTestBase(); err != nil {
+			return fmt.Errorf("生成 Router 测试公共文件失败: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// generateRoutes 生成 routes.go，包含路由注册选项和一次注册全部表路由的 RegisterAll
+func (g *Generator) generateRoutes(tables []TableInfo) error {
+	tmpl := `package router
+
+import (
+	{{- if .Container}}
+	"{{.ServicePackage}}"
+	{{- end}}
+	"github.com/gin-gonic/gin"
+)
+
+// 路由操作名，用于按操作配置中间件和权限
+const (
+	OpCreate      = "create"
+	OpGet         = "get"
+	OpList        = "list"
+	OpUpdate      = "update"
+	OpDelete      = "delete"
+	OpBatchCreate = "batch_create"
+	OpBatchDelete = "batch_delete"
+	OpSearch      = "search"
+)
+
+// writeOps 写操作
+var writeOps = []string{OpCreate, OpUpdate, OpDelete, OpBatchCreate, OpBatchDelete}
+
+// RouteOptions 路由注册选项
+type RouteOptions struct {
+	{{- if .Container}}
+	// Repos handler 使用的 Service，为 nil 时使用默认数据库连接
+	Repos *services.Repos
+	{{- end}}
+	// Middlewares 全部路由共用的中间件
+	Middlewares []gin.HandlerFunc
+	// OpMiddlewares 按操作名配置的中间件，在共用中间件和权限校验之后执行
+	OpMiddlewares map[string][]gin.HandlerFunc
+	// Authorize 根据路由的权限名返回权限校验中间件，为 nil 时不校验
+	Authorize func(permission string) gin.HandlerFunc
+	// Tables 按表名追加的选项，仅在 RegisterAll 中对对应表生效
+	Tables map[string][]RouteOption
+}
+
+// RouteOption 路由注册选项函数
+type RouteOption func(*RouteOptions)
+
+{{- if .Container}}
+
+// WithRepos 指定 handler 使用的 Service，例如 services.NewRepos(db)
+func WithRepos(repos services.Repos) RouteOption {
+	return func(o *RouteOptions) {
+		o.Repos = &repos
+	}
+}
+{{- end}}
+
+// WithMiddleware 为全部路由添加中间件
+func WithMiddleware(middlewares ...gin.HandlerFunc) RouteOption {
+	return func(o *RouteOptions) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// WithOpMiddleware 为指定操作的路由添加中间件
+func WithOpMiddleware(op string, middlewares ...gin.HandlerFunc) RouteOption {
+	return func(o *RouteOptions) {
+		if o.OpMiddlewares == nil {
+			o.OpMiddlewares = make(map[string][]gin.HandlerFunc)
+		}
+		o.OpMiddlewares[op] = append(o.OpMiddlewares[op], middlewares...)
+	}
+}
+
+// WithWriteMiddleware 为全部写操作（创建、更新、删除及批量操作）的路由添加中间件
+func WithWriteMiddleware(middlewares ...gin.HandlerFunc) RouteOption {
+	return func(o *RouteOptions) {
+		for _, op := range writeOps {
+			WithOpMiddleware(op, middlewares...)(o)
+		}
+	}
+}
+
+// WithAuthorizer 设置权限校验，authorize 根据路由的权限名（如 users:delete）返回校验中间件
+func WithAuthorizer(authorize func(permission string) gin.HandlerFunc) RouteOption {
+	return func(o *RouteOptions) {
+		o.Authorize = authorize
+	}
+}
+
+// ForTable 为指定表追加选项，仅在 RegisterAll 中生效
+func ForTable(table string, opts ...RouteOption) RouteOption {
+	return func(o *RouteOptions) {
+		if o.Tables == nil {
+			o.Tables = make(map[string][]RouteOption)
+		}
+		o.Tables[table] = append(o.Tables[table], opts...)
+	}
+}
+
+// newRouteOptions 应用选项
+func newRouteOptions(opts []RouteOption) *RouteOptions {
+	o := &RouteOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// forTable 返回叠加了表级选项的副本
+func (o *RouteOptions) forTable(table string) *RouteOptions {
+	tableOpts := o.Tables[table]
+	if len(tableOpts) == 0 {
+		return o
+	}
+	copied := *o
+	copied.Middlewares = append([]gin.HandlerFunc(nil), o.Middlewares...)
+	copied.OpMiddlewares = make(map[string][]gin.HandlerFunc, len(o.OpMiddlewares))
+	for op, middlewares := range o.OpMiddlewares {
+		copied.OpMiddlewares[op] = append([]gin.HandlerFunc(nil), middlewares...)
+	}
+	for _, opt := range tableOpts {
+		opt(&copied)
+	}
+	return &copied
+}
+
+{{- if .Container}}
+
+// repos 获取 handler 使用的 Service
+func (o *RouteOptions) repos() services.Repos {
+	if o.Repos != nil {
+		return *o.Repos
+	}
+	return services.NewRepos(nil)
+}
+{{- end}}
+
+// handlers 组装路由的处理链：共用中间件、权限校验、操作中间件、handler
+func (o *RouteOptions) handlers(op, permission string, handler gin.HandlerFunc) []gin.HandlerFunc {
+	chain := append([]gin.HandlerFunc(nil), o.Middlewares...)
+	if o.Authorize != nil && permission != "" {
+		chain = append(chain, o.Authorize(permission))
+	}
+	chain = append(chain, o.OpMiddlewares[op]...)
+	return append(chain, handler)
+}
+
+// RegisterAll 注册全部表的路由
+func RegisterAll(r *gin.RouterGroup, opts ...RouteOption) {
+	o := newRouteOptions(opts)
+	{{- if .Container}}
+	deps := o.repos()
+	{{- range .Routes}}
+	register{{.ModelName}}Routes(r, New{{.HandlerName}}WithService(deps.{{.FieldName}}), o.forTable("{{.TableName}}"))
+	{{- end}}
+	{{- else}}
+	{{- range .Routes}}
+	register{{.ModelName}}Routes(r, New{{.HandlerName}}(), o.forTable("{{.TableName}}"))
+	{{- end}}
+	{{- end}}
+}
+`
+
+	t, err := template.New("routes").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	var routes []map[string]interface{}
+	for _, table := range tables {
+		routes = append(routes, map[string]interface{}{
+			"TableName":   table.Name,
+			"ModelName":   g.modelName(table),
+			"HandlerName": g.modelName(table) + "Handler",
+			"FieldName":   g.pluralName(table),
+		})
+	}
+
+	return writeGoFile(filepath.Join(g.config.RouterOutput, "routes.go"), t, map[string]interface{}{
+		"ServicePackage": g.config.ServiceImportPath,
+		"Routes":         routes,
+		"Container":      !g.config.SkipContainer,
+	})
+}
+
+// 路由操作名，与生成代码中的 Op 常量一致
+const (
+	opCreate      = "create"
+	opGet         = "get"
+	opList        = "list"
+	opUpdate      = "update"
+	opDelete      = "delete"
+	opBatchCreate = "batch_create"
+	opBatchDelete = "batch_delete"
+	opSearch      = "search"
+)
+
+// routeOperations 全部路由操作，顺序即 Permissions 的生成顺序
+var routeOperations = []string{opCreate, opGet, opList, opUpdate, opDelete, opBatchCreate, opBatchDelete, opSearch}
+
+// operationAliases 操作配置中可使用的别名
+var operationAliases = map[string][]string{
+	"read":  {opGet, opList, opSearch},
+	"write": {opCreate, opUpdate, opDelete, opBatchCreate, opBatchDelete},
+	"all":   routeOperations,
+}
+
+// getOperations 获取表需要生成的路由操作，未配置时生成全部；
+// 视图去掉写操作，需要主键的操作在无主键时、搜索在无搜索字段时自动去掉
+func (g *Generator) getOperations(table TableInfo, hasPK, hasSearch bool) map[string]bool {
+	configured, ok := g.config.Operations[table.Name]
+	if !ok {
+		configured = []string{"all"}
+	}
+
+	ops := make(map[string]bool)
+	for _, name := range configured {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if alias, ok := operationAliases[name]; ok {
+			for _, op := range alias {
+				ops[op] = true
+			}
+			continue
+		}
+		if !contains(routeOperations, name) {
+			log.Printf("警告: 表 %s 配置了未知的路由操作 %s", table.Name, name)
+			continue
+		}
+		ops[name] = true
+	}
+
+	if table.IsView {
+		for _, op := range operationAliases["write"] {
+			delete(ops, op)
+		}
+	}
+	if !hasPK {
+		delete(ops, opGet)
+		delete(ops, opUpdate)
+		delete(ops, opDelete)
+		delete(ops, opBatchDelete)
+	}
+	if !hasSearch {
+		delete(ops, opSearch)
+	}
+	return ops
+}
+
+// getPermissions 按 PermissionFormat 生成各操作的权限名，{table} 替换为表名，{op} 替换为操作名
+func (g *Generator) getPermissions(table TableInfo, ops map[string]bool) []map[string]string {
+	var result []map[string]string
+	for _, op := range routeOperations {
+		if !ops[op] {
+			continue
+		}
+		name := strings.NewReplacer("{table}", table.Name, "{op}", op).Replace(g.config.PermissionFormat)
+		result = append(result, map[string]string{
+			"Op":   "Op" + g.toCamelCase(op),
+			"Name": name,
+		})
+	}
+	return result
+}
+
 // generateRouterBase 生成 Router 基础文件
 func (g *Generator) generateRouterBase() error {
 	tmpl := `package router
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
+	"{{.ServicePackage}}"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 // Response 统一响应结构
@@ -60,14 +351,78 @@ func Success(c *gin.Context, data interface{}) {
 	})
 }
 
+// UseHTTPStatus 为 true 时错误响应使用与 Code 对应的 HTTP 状态码；为 false 时始终返回 200，仅在响应体中携带 Code
+const UseHTTPStatus = {{.UseHTTPStatus}}
+
 // Error 错误响应
 func Error(c *gin.Context, code int, message string) {
-	c.JSON(http.StatusOK, Response{
+	status := http.StatusOK
+	if UseHTTPStatus {
+		status = code
+		if http.StatusText(status) == "" {
+			status = http.StatusInternalServerError
+		}
+	}
+	c.JSON(status, Response{
 		Code:    code,
 		Message: message,
 	})
 }
 
+// RespondError 根据错误类型响应：数据库错误先转换为 ServiceError 并使用其 Code 和消息，
+// 无法识别的错误只记录日志，客户端收到 500 和 fallback 消息，避免泄露数据库错误细节
+func RespondError(c *gin.Context, err error, fallback string) {
+	var serviceErr services.ServiceError
+	if errors.As(services.TranslateError(err), &serviceErr) && serviceErr.Code != http.StatusInternalServerError {
+		Error(c, serviceErr.Code, serviceErr.Message)
+		return
+	}
+	log.Printf("%s %s: %v", c.Request.Method, c.FullPath(), err)
+	if fallback == "" {
+		fallback = services.ErrInternal.Error()
+	}
+	Error(c, http.StatusInternalServerError, fallback)
+}
+
+// RespondBindError 响应请求参数绑定错误，校验失败时返回 422 并列出未通过校验的字段；
+// 枚举字段的非法取值在 JSON 解析时即被拒绝（早于 binding 标签校验），同样返回 422
+func RespondBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]string, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fe.Field())
+		}
+		RespondError(c, services.NewValidationError("参数校验失败: "+strings.Join(fields, ", ")), "")
+		return
+	}
+	if _, ok := services.TranslateError(err).(services.ServiceError); ok {
+		RespondError(c, err, "")
+		return
+	}
+	Error(c, http.StatusBadRequest, "请求参数格式错误")
+}
+{{- if .TenantContextKey}}
+
+// TenantContextKey 租户 ID 在 gin 上下文中的键，由认证中间件通过 c.Set 写入
+const TenantContextKey = "{{.TenantContextKey}}"
+
+// RequestContext 获取请求 context，并附带 gin 上下文中的租户 ID
+func RequestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if tenantID, ok := c.Get(TenantContextKey); ok {
+		ctx = services.WithTenant(ctx, tenantID)
+	}
+	return ctx
+}
+{{- else}}
+
+// RequestContext 获取请求 context
+func RequestContext(c *gin.Context) context.Context {
+	return c.Request.Context()
+}
+{{- end}}
+
 // GetPageParams 获取分页参数
 func GetPageParams(c *gin.Context) (int, int) {
 	pageStr := c.DefaultQuery("page", "1")
@@ -86,25 +441,152 @@ func GetPageParams(c *gin.Context) (int, int) {
 	return page, pageSize
 }
 
+// MaxBatchItems 批量接口单次允许的最大条数
+const MaxBatchItems = 1000
+
+// reservedQueryKeys 不作为过滤条件的查询参数
+var reservedQueryKeys = map[string]bool{
+	"page":       true,
+	"page_size":  true,
+	"sort":       true,
+	"fields":     true,
+	"keyword":    true,
+	"cursor":     true,
+	"order":      true,
+	"with_total": true,
+}
+
+// GetListQuery 获取列表查询参数
+//
+// 过滤: ?name=foo（等于）、?age[gt]=18、?status[in]=1,2、?name[like]=foo
+// 排序: ?sort=-created_at,name（- 表示降序）
+// 字段: ?fields=id,name
+// 总数: ?with_total=false 时不统计总数
+func GetListQuery(c *gin.Context) services.ListQuery {
+	page, pageSize := GetPageParams(c)
+	return services.ListQuery{
+		Page:      page,
+		PageSize:  pageSize,
+		Filters:   parseFilters(c),
+		Sorts:     parseSorts(c.Query("sort")),
+		Fields:    splitParam(c.Query("fields")),
+		SkipTotal: c.Query("with_total") == "false",
+	}
+}
+
+// GetCursorQuery 获取游标分页查询参数
+//
+// 游标: ?cursor=<上一页返回的 next_cursor>
+// 排序: ?order=desc 按游标键降序
+// 总数: ?with_total=true 时额外统计总数
+// 过滤与字段参数同 GetListQuery
+func GetCursorQuery(c *gin.Context) services.CursorQuery {
+	_, pageSize := GetPageParams(c)
+	return services.CursorQuery{
+		Cursor:    c.Query("cursor"),
+		PageSize:  pageSize,
+		Desc:      c.Query("order") == "desc",
+		Filters:   parseFilters(c),
+		Fields:    splitParam(c.Query("fields")),
+		WithTotal: c.Query("with_total") == "true",
+	}
+}
+
+// parseFilters 解析过滤参数
+func parseFilters(c *gin.Context) []services.Filter {
+	var filters []services.Filter
+	params := c.Request.URL.Query()
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if !reservedQueryKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		column, op := key, services.OpEq
+		if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+			column, op = key[:i], key[i+1:len(key)-1]
+		}
+		for _, value := range params[key] {
+			values := []string{value}
+			if op == services.OpIn {
+				values = splitParam(value)
+			}
+			filters = append(filters, services.Filter{Column: column, Op: op, Values: values})
+		}
+	}
+	return filters
+}
+
+// parseSorts 解析排序参数
+func parseSorts(raw string) []services.SortField {
+	var sorts []services.SortField
+	for _, part := range splitParam(raw) {
+		sorts = append(sorts, services.SortField{
+			Column: strings.TrimPrefix(part, "-"),
+			Desc:   strings.HasPrefix(part, "-"),
+		})
+	}
+	return sorts
+}
+
+// splitParam 按逗号拆分参数并去除空白项
+func splitParam(raw string) []string {
+	var result []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
 // GetIDParam 获取ID参数
 func GetIDParam(c *gin.Context) (uint, error) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	return ParamUint(c, "id")
+}
+
+// ParamUint 获取无符号整型路径参数
+func ParamUint(c *gin.Context, name string) (uint, error) {
+	v, err := strconv.ParseUint(c.Param(name), 10, 64)
 	if err != nil {
 		return 0, err
 	}
-	return uint(id), nil
+	return uint(v), nil
+}
+
+// ParamInt 获取整型路径参数
+func ParamInt(c *gin.Context, name string) (int, error) {
+	return strconv.Atoi(c.Param(name))
+}
+
+// ParamInt64 获取 int64 路径参数
+func ParamInt64(c *gin.Context, name string) (int64, error) {
+	return strconv.ParseInt(c.Param(name), 10, 64)
+}
+
+// ParamString 获取非空字符串路径参数
+func ParamString(c *gin.Context, name string) (string, error) {
+	v := c.Param(name)
+	if v == "" {
+		return "", fmt.Errorf("missing path param: %s", name)
+	}
+	return v, nil
 }
 `
 
-	file, err := os.Create(filepath.Join(g.config.RouterOutput, "base.go"))
+	t, err := template.New("router_base").Parse(tmpl)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	_, err = file.WriteString(tmpl)
-	return err
+	return writeGoFile(filepath.Join(g.config.RouterOutput, "base.go"), t, map[string]interface{}{
+		"ServicePackage":   g.config.ServiceImportPath,
+		"TenantContextKey": g.config.TenantContextKey,
+		"UseHTTPStatus":    g.config.StatusMode == StatusModeHTTP,
+	})
 }
 
 // generateTableRouter 生成表 Router
@@ -112,39 +594,62 @@ func (g *Generator) generateTableRouter(table TableInfo) error {
 	tmpl := `package router
 
 import (
-	"net/http"
+	{{- if .NeedFmt}}
+	"fmt"
+	{{- end}}
+	{{- if .Ops.batch_create}}
 	"strconv"
+	{{- end}}
+	{{- if .NeedTime}}
+	"time"
+	{{- end}}
 
+	{{- if .NeedModel}}
 	"{{.ModelPackage}}"
+	{{- end}}
 	"{{.ServicePackage}}"
 	"github.com/gin-gonic/gin"
 )
 
+// {{.ModelName}}Permissions {{.Comment}}各路由操作对应的权限名
+var {{.ModelName}}Permissions = map[string]string{
+	{{- range .Permissions}}
+	{{.Op}}: "{{.Name}}",
+	{{- end}}
+}
+
 // {{.HandlerName}} {{.Comment}}处理器
 type {{.HandlerName}} struct {
-	{{.ServiceVarName}} *{{.ServiceName}}
+	{{.ServiceVarName}} *services.{{.ServiceName}}
 }
 
-// New{{.HandlerName}} 创建{{.Comment}}处理器
+// New{{.HandlerName}} 创建使用默认数据库连接的{{.Comment}}处理器
 func New{{.HandlerName}}() *{{.HandlerName}} {
+	return New{{.HandlerName}}WithService(services.New{{.ServiceName}}())
+}
+
+// New{{.HandlerName}}WithService 使用指定的 Service 创建{{.Comment}}处理器
+func New{{.HandlerName}}WithService({{.ServiceVarName}} *services.{{.ServiceName}}) *{{.HandlerName}} {
 	return &{{.HandlerName}}{
-		{{.ServiceVarName}}: {{.ServicePackage}}.New{{.ServiceName}}(),
+		{{.ServiceVarName}}: {{.ServiceVarName}},
 	}
 }
 
+{{- if .Ops.create}}
+
 // Create{{.ModelName}} 创建{{.Comment}}
 func (h *{{.HandlerName}}) Create{{.ModelName}}(c *gin.Context) {
-	var {{.ModelVarName}} {{.ModelName}}
+	var {{.ModelVarName}} {{.ModelType}}
 	if err := c.ShouldBindJSON(&{{.ModelVarName}}); err != nil {
-		Error(c, 400, "请求参数错误: "+err.Error())
+		RespondBindError(c, err)
 		return
 	}
 
 	{{- if .HasUniqueFields}}
 	{{- range .UniqueFields}}
 	// 检查{{.Comment}}是否已存在
-	if {{.ModelVarName}}.{{.GoName}} != "" {
-		if _, err := h.{{$.ServiceVarName}}.GetBy{{.GoName}}({{.ModelVarName}}.{{.GoName}}); err == nil {
+	if {{$.ModelVarName}}.{{.GoName}} != {{.ZeroValue}} {
+		if _, err := h.{{$.ServiceVarName}}.GetBy{{.GoName}}(RequestContext(c), {{$.ModelVarName}}.{{.GoName}}); err == nil {
 			Error(c, 409, "{{.Comment}}已存在")
 			return
 		}
@@ -152,48 +657,75 @@ func (h *{{.HandlerName}}) Create{{.ModelName}}(c *gin.Context) {
 	{{- end}}
 	{{- end}}
 
-	if err := h.{{.ServiceVarName}}.Create(&{{.ModelVarName}}); err != nil {
-		Error(c, 500, "创建{{.Comment}}失败: "+err.Error())
+	if err := h.{{.ServiceVarName}}.Create(RequestContext(c), &{{.ModelVarName}}); err != nil {
+		RespondError(c, err, "创建{{.Comment}}失败")
 		return
 	}
 
 	Success(c, {{.ModelVarName}})
 }
+{{- end}}
+
+{{- if .NeedKey}}
+
+// parse{{.ModelName}}Key 解析{{.Comment}}主键路径参数
+func parse{{.ModelName}}Key(c *gin.Context) ({{.PK.Params}}, err error) {
+	{{- range .PK.Keys}}
+	if {{.VarName}}, err = {{.ParamFunc}}(c, "{{.DBName}}"); err != nil {
+		return
+	}
+	{{- end}}
+	return
+}
+{{- end}}
+
+{{- if .Ops.get}}
 
 // Get{{.ModelName}} 获取{{.Comment}}
 func (h *{{.HandlerName}}) Get{{.ModelName}}(c *gin.Context) {
-	id, err := GetIDParam(c)
+	{{.PK.Args}}, err := parse{{.ModelName}}Key(c)
 	if err != nil {
 		Error(c, 400, "无效的ID")
 		return
 	}
 
-	{{.ModelVarName}}, err := h.{{.ServiceVarName}}.GetByID(id)
+	{{.ModelVarName}}, err := h.{{.ServiceVarName}}.GetByID(RequestContext(c), {{.PK.Args}})
 	if err != nil {
-		Error(c, 404, "{{.Comment}}不存在")
+		if services.IsNotFound(err) {
+			Error(c, 404, "{{.Comment}}不存在")
+			return
+		}
+		RespondError(c, err, "获取{{.Comment}}失败")
 		return
 	}
 
 	Success(c, {{.ModelVarName}})
 }
+{{- end}}
+
+{{- if .Ops.update}}
 
 // Update{{.ModelName}} 更新{{.Comment}}
 func (h *{{.HandlerName}}) Update{{.ModelName}}(c *gin.Context) {
-	id, err := GetIDParam(c)
+	{{.PK.Args}}, err := parse{{.ModelName}}Key(c)
 	if err != nil {
 		Error(c, 400, "无效的ID")
 		return
 	}
 
-	var updateData {{.ModelName}}
+	var updateData {{.ModelType}}
 	if err := c.ShouldBindJSON(&updateData); err != nil {
-		Error(c, 400, "请求参数错误: "+err.Error())
+		RespondBindError(c, err)
 		return
 	}
 
-	{{.ModelVarName}}, err := h.{{.ServiceVarName}}.GetByID(id)
+	{{.ModelVarName}}, err := h.{{.ServiceVarName}}.GetByID(RequestContext(c), {{.PK.Args}})
 	if err != nil {
-		Error(c, 404, "{{.Comment}}不存在")
+		if services.IsNotFound(err) {
+			Error(c, 404, "{{.Comment}}不存在")
+			return
+		}
+		RespondError(c, err, "获取{{.Comment}}失败")
 		return
 	}
 
@@ -203,52 +735,136 @@ func (h *{{.HandlerName}}) Update{{.ModelName}}(c *gin.Context) {
 		{{$.ModelVarName}}.{{.GoName}} = updateData.{{.GoName}}
 	}
 	{{- end}}
+	{{- if .Version}}
+	// 乐观锁：使用客户端提交的版本号，与数据库不一致时返回冲突
+	{{.ModelVarName}}.{{.Version.GoName}} = updateData.{{.Version.GoName}}
+	{{- end}}
 
-	if err := h.{{.ServiceVarName}}.Update({{.ModelVarName}}); err != nil {
-		Error(c, 500, "更新{{.Comment}}失败: "+err.Error())
+	if err := h.{{.ServiceVarName}}.Update(RequestContext(c), {{.ModelVarName}}); err != nil {
+		RespondError(c, err, "更新{{.Comment}}失败")
 		return
 	}
 
 	Success(c, {{.ModelVarName}})
 }
+{{- end}}
+
+{{- if .Ops.delete}}
 
 // Delete{{.ModelName}} 删除{{.Comment}}
 func (h *{{.HandlerName}}) Delete{{.ModelName}}(c *gin.Context) {
-	id, err := GetIDParam(c)
+	{{.PK.Args}}, err := parse{{.ModelName}}Key(c)
 	if err != nil {
 		Error(c, 400, "无效的ID")
 		return
 	}
 
-	if err := h.{{.ServiceVarName}}.Delete(id); err != nil {
-		Error(c, 500, "删除{{.Comment}}失败: "+err.Error())
+	if err := h.{{.ServiceVarName}}.Delete(RequestContext(c), {{.PK.Args}}); err != nil {
+		RespondError(c, err, "删除{{.Comment}}失败")
 		return
 	}
 
 	Success(c, gin.H{"message": "删除成功"})
 }
+{{- end}}
 
-// List{{.ModelName}}s 获取{{.Comment}}列表
-func (h *{{.HandlerName}}) List{{.ModelName}}s(c *gin.Context) {
-	page, pageSize := GetPageParams(c)
+{{- if .Ops.batch_create}}
+
+// BatchCreate{{.PluralName}} 批量创建{{.Comment}}，请求体为 JSON 数组，?batch_size= 指定每批条数
+func (h *{{.HandlerName}}) BatchCreate{{.PluralName}}(c *gin.Context) {
+	var {{.PluralVarName}} []{{.ModelType}}
+	if err := c.ShouldBindJSON(&{{.PluralVarName}}); err != nil {
+		RespondBindError(c, err)
+		return
+	}
+	if len({{.PluralVarName}}) == 0 || len({{.PluralVarName}}) > MaxBatchItems {
+		Error(c, 400, fmt.Sprintf("批量条数必须在 1 到 %d 之间", MaxBatchItems))
+		return
+	}
 
-	{{.ModelVarName}}s, total, err := h.{{.ServiceVarName}}.List(page, pageSize)
+	batchSize, _ := strconv.Atoi(c.Query("batch_size"))
+	if err := h.{{.ServiceVarName}}.CreateBatch(RequestContext(c), {{.PluralVarName}}, batchSize); err != nil {
+		RespondError(c, err, "批量创建{{.Comment}}失败")
+		return
+	}
+
+	Success(c, {{.PluralVarName}})
+}
+{{- end}}
+
+{{- if .Ops.batch_delete}}
+
+// BatchDelete{{.PluralName}} 根据主键批量删除{{.Comment}}，请求体为 {"ids": [...]}
+func (h *{{.HandlerName}}) BatchDelete{{.PluralName}}(c *gin.Context) {
+	var req struct {
+		IDs []{{if .PK.Multiple}}services.{{end}}{{.PK.KeyType}} ` + "`json:\"ids\" binding:\"required\"`" + `
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindError(c, err)
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > MaxBatchItems {
+		Error(c, 400, fmt.Sprintf("批量条数必须在 1 到 %d 之间", MaxBatchItems))
+		return
+	}
+
+	if err := h.{{.ServiceVarName}}.DeleteByIDs(RequestContext(c), req.IDs); err != nil {
+		RespondError(c, err, "批量删除{{.Comment}}失败")
+		return
+	}
+
+	Success(c, gin.H{"message": "删除成功"})
+}
+{{- end}}
+
+{{- if .Ops.list}}
+
+// List{{.PluralName}} 获取{{.Comment}}列表
+func (h *{{.HandlerName}}) List{{.PluralName}}(c *gin.Context) {
+	{{- if .Cursor}}
+	q := GetCursorQuery(c)
+
+	{{.PluralVarName}}, page, err := h.{{.ServiceVarName}}.ListByCursor(RequestContext(c), q)
 	if err != nil {
-		Error(c, 500, "获取{{.Comment}}列表失败: "+err.Error())
+		RespondError(c, err, "获取{{.Comment}}列表失败")
 		return
 	}
 
-	Success(c, gin.H{
-		"list":      {{.ModelVarName}}s,
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-	})
+	result := gin.H{
+		"list":        {{.PluralVarName}},
+		"next_cursor": page.NextCursor,
+		"page_size":   q.PageSize,
+	}
+	if page.Total != nil {
+		result["total"] = *page.Total
+	}
+	Success(c, result)
+	{{- else}}
+	q := GetListQuery(c)
+
+	{{.PluralVarName}}, total, err := h.{{.ServiceVarName}}.List(RequestContext(c), q)
+	if err != nil {
+		RespondError(c, err, "获取{{.Comment}}列表失败")
+		return
+	}
+
+	result := gin.H{
+		"list":      {{.PluralVarName}},
+		"page":      q.Page,
+		"page_size": q.PageSize,
+	}
+	if !q.SkipTotal {
+		result["total"] = total
+	}
+	Success(c, result)
+	{{- end}}
 }
+{{- end}}
 
-{{- if .HasSearchFields}}
-// Search{{.ModelName}}s 搜索{{.Comment}}
-func (h *{{.HandlerName}}) Search{{.ModelName}}s(c *gin.Context) {
+{{- if .Ops.search}}
+
+// Search{{.PluralName}} 搜索{{.Comment}}
+func (h *{{.HandlerName}}) Search{{.PluralName}}(c *gin.Context) {
 	keyword := c.Query("keyword")
 	if keyword == "" {
 		Error(c, 400, "搜索关键词不能为空")
@@ -257,14 +873,14 @@ func (h *{{.HandlerName}}) Search{{.ModelName}}s(c *gin.Context) {
 
 	page, pageSize := GetPageParams(c)
 
-	{{.ModelVarName}}s, total, err := h.{{.ServiceVarName}}.Search(keyword, page, pageSize)
+	{{.PluralVarName}}, total, err := h.{{.ServiceVarName}}.Search(RequestContext(c), keyword, page, pageSize)
 	if err != nil {
-		Error(c, 500, "搜索{{.Comment}}失败: "+err.Error())
+		RespondError(c, err, "搜索{{.Comment}}失败")
 		return
 	}
 
 	Success(c, gin.H{
-		"list":      {{.ModelVarName}}s,
+		"list":      {{.PluralVarName}},
 		"total":     total,
 		"page":      page,
 		"page_size": pageSize,
@@ -272,21 +888,49 @@ func (h *{{.HandlerName}}) Search{{.ModelName}}s(c *gin.Context) {
 }
 {{- end}}
 
-// Register{{.ModelName}}Routes 注册{{.Comment}}路由
-func Register{{.ModelName}}Routes(r *gin.RouterGroup) {
+// Register{{.ModelName}}Routes 注册{{.Comment}}路由，opts 可为各操作配置中间件和权限校验
+func Register{{.ModelName}}Routes(r *gin.RouterGroup, opts ...RouteOption) {
+	o := newRouteOptions(opts)
 	handler := New{{.HandlerName}}()
-	
+	{{- if .Container}}
+	if o.Repos != nil {
+		handler = New{{.HandlerName}}WithService(o.Repos.{{.RepoName}})
+	}
+	{{- end}}
+	register{{.ModelName}}Routes(r, handler, o)
+}
+
+// register{{.ModelName}}Routes 使用指定的处理器注册{{.Comment}}路由
+func register{{.ModelName}}Routes(r *gin.RouterGroup, handler *{{.HandlerName}}, o *RouteOptions) {
+	{{- if .Ops}}
 	{{.RouteGroup}} := r.Group("/{{.RoutePath}}")
 	{
-		{{.RouteGroup}}.POST("", handler.Create{{.ModelName}})
-		{{.RouteGroup}}.GET("", handler.List{{.ModelName}}s)
-		{{.RouteGroup}}.GET("/:id", handler.Get{{.ModelName}})
-		{{.RouteGroup}}.PUT("/:id", handler.Update{{.ModelName}})
-		{{.RouteGroup}}.DELETE("/:id", handler.Delete{{.ModelName}})
-		{{- if .HasSearchFields}}
-		{{.RouteGroup}}.GET("/search", handler.Search{{.ModelName}}s)
+		{{- if .Ops.create}}
+		{{.RouteGroup}}.POST("", o.handlers(OpCreate, {{.ModelName}}Permissions[OpCreate], handler.Create{{.ModelName}})...)
+		{{- end}}
+		{{- if .Ops.batch_create}}
+		{{.RouteGroup}}.POST("/batch", o.handlers(OpBatchCreate, {{.ModelName}}Permissions[OpBatchCreate], handler.BatchCreate{{.PluralName}})...)
+		{{- end}}
+		{{- if .Ops.list}}
+		{{.RouteGroup}}.GET("", o.handlers(OpList, {{.ModelName}}Permissions[OpList], handler.List{{.PluralName}})...)
+		{{- end}}
+		{{- if .Ops.search}}
+		{{.RouteGroup}}.GET("/search", o.handlers(OpSearch, {{.ModelName}}Permissions[OpSearch], handler.Search{{.PluralName}})...)
+		{{- end}}
+		{{- if .Ops.get}}
+		{{.RouteGroup}}.GET("{{.PK.Path}}", o.handlers(OpGet, {{.ModelName}}Permissions[OpGet], handler.Get{{.ModelName}})...)
+		{{- end}}
+		{{- if .Ops.update}}
+		{{.RouteGroup}}.PUT("{{.PK.Path}}", o.handlers(OpUpdate, {{.ModelName}}Permissions[OpUpdate], handler.Update{{.ModelName}})...)
+		{{- end}}
+		{{- if .Ops.delete}}
+		{{.RouteGroup}}.DELETE("{{.PK.Path}}", o.handlers(OpDelete, {{.ModelName}}Permissions[OpDelete], handler.Delete{{.ModelName}})...)
+		{{- end}}
+		{{- if .Ops.batch_delete}}
+		{{.RouteGroup}}.DELETE("/batch", o.handlers(OpBatchDelete, {{.ModelName}}Permissions[OpBatchDelete], handler.BatchDelete{{.PluralName}})...)
 		{{- end}}
 	}
+	{{- end}}
 }
 `
 
@@ -295,36 +939,69 @@ func Register{{.ModelName}}Routes(r *gin.RouterGroup) {
 		return err
 	}
 
+	version := g.getVersionColumn(table)
+	updateableFields := g.getEditableFields(table)
+	var pk map[string]interface{}
+	if keys := g.getPrimaryKeyFields(table); len(keys) > 0 {
+		pk = g.primaryKeyTemplateData(table, keys)
+	}
+	uniqueFields := g.getUniqueFields(table.Columns)
+	ops := g.getOperations(table, pk != nil, len(g.getSearchFields(table)) > 0)
+
 	// 准备模板数据
 	data := map[string]interface{}{
 		"ModelPackage":     g.config.ModelImportPath,
 		"ServicePackage":   g.config.ServiceImportPath,
-		"HandlerName":      g.toCamelCase(table.Name) + "Handler",
-		"ServiceName":      g.toCamelCase(table.Name) + "Service",
-		"ServiceVarName":   g.toLowerCamelCase(table.Name) + "Service",
-		"ModelName":        g.toCamelCase(table.Name),
-		"ModelVarName":     g.toLowerCamelCase(table.Name),
+		"HandlerName":      g.modelName(table) + "Handler",
+		"ServiceName":      g.modelName(table) + "Service",
+		"ServiceVarName":   g.toLowerCamelCase(g.modelName(table)) + "Service",
+		"ModelName":        g.modelName(table),
+		"ModelType":        g.modelPackageName() + "." + g.modelName(table),
+		"ModelVarName":     g.toVarName(g.modelName(table)),
+		"PluralName":       g.pluralName(table),
+		"PluralVarName":    g.toVarName(g.pluralVarName(table)),
+		"RepoName":         g.pluralName(table),
+		"Container":        !g.config.SkipContainer,
 		"Comment":          table.Comment,
-		"RouteGroup":       g.toLowerCamelCase(table.Name) + "Group",
-		"RoutePath":        g.toSnakeCase(table.Name),
-		"UniqueFields":     g.getUniqueFields(table.Columns),
-		"UpdateableFields": g.getUpdateableFields(table.Columns),
-		"SearchFields":     g.getSearchFields(table.Columns),
-		"HasUniqueFields":  len(g.getUniqueFields(table.Columns)) > 0,
-		"HasSearchFields":  len(g.getSearchFields(table.Columns)) > 0,
+		"RouteGroup":       g.toLowerCamelCase(g.modelName(table)) + "Group",
+		"RoutePath":        g.routePath(table),
+		"UniqueFields":     uniqueFields,
+		"UpdateableFields": updateableFields,
+		"HasUniqueFields":  len(uniqueFields) > 0,
+		"NeedTime":         (ops[opCreate] && g.needTimeZeroValue(uniqueFields)) || (ops[opUpdate] && g.needTimeZeroValue(updateableFields)),
+		"NeedFmt":          ops[opBatchCreate] || ops[opBatchDelete],
+		"NeedKey":          ops[opGet] || ops[opUpdate] || ops[opDelete],
+		"NeedModel":        ops[opCreate] || ops[opUpdate] || ops[opBatchCreate],
+		"Ops":              ops,
+		"Permissions":      g.getPermissions(table, ops),
+		"Cursor":           len(g.getCursorKeys(table)) > 0,
+		"PK":               pk,
+		"Version":          version,
 	}
 
 	// 生成文件名
-	fileName := g.toSnakeCase(table.Name) + "_router.go"
+	fileName := g.fileName(table) + "_router.go"
 	filePath := filepath.Join(g.config.RouterOutput, fileName)
 
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	return writeGoFile(filePath, t, data)
+}
 
-	return t.Execute(file, data)
+// getEditableFields 获取更新接口按非零值复制的字段：
+// 版本字段由乐观锁逻辑单独处理，租户字段由 Service 从 context 填充
+func (g *Generator) getEditableFields(table TableInfo) []map[string]interface{} {
+	version := g.getVersionColumn(table)
+	tenant := g.getTenantColumn(table)
+	var result []map[string]interface{}
+	for _, field := range g.getUpdateableFields(g.modelColumns(table)) {
+		if version != nil && field["GoName"] == version["GoName"] {
+			continue
+		}
+		if tenant != nil && field["GoName"] == tenant["GoName"] {
+			continue
+		}
+		result = append(result, field)
+	}
+	return result
 }
 
 // getUpdateableFields 获取可更新字段
@@ -332,12 +1009,12 @@ func (g *Generator) getUpdateableFields(columns []ColumnInfo) []map[string]inter
 	var result []map[string]interface{}
 	for _, col := range columns {
 		// 排除主键、创建时间等不可更新字段
-		if !col.IsPrimaryKey && 
-		   !strings.Contains(strings.ToLower(col.Name), "created_at") &&
-		   !strings.Contains(strings.ToLower(col.Name), "id") {
+		if !col.IsPrimaryKey &&
+			!strings.Contains(strings.ToLower(col.Name), "created_at") &&
+			!strings.Contains(strings.ToLower(col.Name), "id") {
 			result = append(result, map[string]interface{}{
-				"GoName":     g.toCamelCase(col.Name),
-				"ZeroValue":  g.getZeroValue(col.GoType),
+				"GoName":    g.columnGoName(col),
+				"ZeroValue": g.getZeroValue(baseGoType(col)),
 			})
 		}
 	}
@@ -356,7 +1033,8 @@ func (g *Generator) getZeroValue(goType string) string {
 	case "bool":
 		return "false"
 	case "time.Time":
-		return "time.Time{}"
+		// 复合字面量出现在 if 条件中需要加括号
+		return "(time.Time{})"
 	default:
 		if strings.HasPrefix(goType, "*") {
 			return "nil"
@@ -364,3 +1042,13 @@ func (g *Generator) getZeroValue(goType string) string {
 		return "nil"
 	}
 }
+
+// needTimeZeroValue 判断字段零值比较是否引用 time 包
+func (g *Generator) needTimeZeroValue(fields []map[string]interface{}) bool {
+	for _, field := range fields {
+		if field["ZeroValue"] == "(time.Time{})" {
+			return true
+		}
+	}
+	return false
+}
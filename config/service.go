@@ -22,12 +22,34 @@ func (g *Generator) generateServices(tables []TableInfo) error {
 	}
 
 	// 为每个表生成 Service
+	var generated []TableInfo
 	for _, table := range tables {
 		if err := g.generateTableService(table); err != nil {
 			log.Printf("生成表 %s 的 Service 失败: %v", table.Name, err)
 			continue
 		}
+		generated = append(generated, table)
 		fmt.Printf("生成表 %s 的 Service 成功\n", table.Name)
+
+		if g.config.GenerateTests {
+			if err := g.generateTableServiceTest(table); err != nil {
+				log.Printf("生成表 %s 的 Service 测试失败: %v", table.Name, err)
+			}
+		}
+	}
+
+	// 生成 Service 容器
+	if !g.config.SkipContainer {
+		if err := g.generateServiceContainer(generated); err != nil {
+			return fmt.Errorf("生成 Service 容器失败: %w", err)
+		}
+	}
+
+	// 生成 Service 测试公共文件
+	if g.config.GenerateTests {
+		if err := g.generateServiceTestBase(); err != nil {
+			return fmt.Errorf("生成 Service 测试公共文件失败: %w", err)
+		}
 	}
 
 	return nil
@@ -38,19 +60,22 @@ func (g *Generator) generateServiceBase() error {
 	tmpl := `package services
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"{{.ModelPackage}}"
+
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// BaseService 基础服务接口
-type BaseService interface {
-	Create(model interface{}) error
-	GetByID(id uint) (interface{}, error)
-	Update(model interface{}) error
-	Delete(id uint) error
-	List(page, pageSize int) ([]interface{}, int64, error)
-}
-
 // ServiceError 服务错误
 type ServiceError struct {
 	Code    int
@@ -69,20 +94,529 @@ func NewServiceError(code int, message string) error {
 	}
 }
 
+// ErrVersionConflict 乐观锁版本冲突：记录已被其他请求修改
+var ErrVersionConflict = NewServiceError(409, "数据已被修改，请刷新后重试")
+
+// ErrMissingTenant context 中缺少租户信息
+var ErrMissingTenant = NewServiceError(403, "缺少租户信息")
+
+// ErrMissingShardKey context 中缺少分片键
+var ErrMissingShardKey = NewServiceError(400, "缺少分片键")
+
+// shardKey context 中分片键的键
+type shardKey struct{}
+
+// WithShardKey 返回携带分片键的 context，分表的 Service 据此选择分表
+func WithShardKey(ctx context.Context, key int64) context.Context {
+	return context.WithValue(ctx, shardKey{}, key)
+}
+
+// ShardScope 按 context 中的分片键选择分表，缺少分片键时查询返回 ErrMissingShardKey，未设置分表函数时返回错误
+func ShardScope(ctx context.Context, shard func(key int64) string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if shard == nil {
+			db.AddError(errors.New("未设置分表函数"))
+			return db
+		}
+		key, ok := ctx.Value(shardKey{}).(int64)
+		if !ok {
+			db.AddError(ErrMissingShardKey)
+			return db
+		}
+		return db.Table(shard(key))
+	}
+}
+
+// tenantKey context 中租户 ID 的键
+type tenantKey struct{}
+
+// WithTenant 返回携带租户 ID 的 context，多租户表的 Service 据此限定所有查询
+func WithTenant(ctx context.Context, tenantID interface{}) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantScope 按 context 中的租户 ID 限定查询，缺少租户时查询返回 ErrMissingTenant
+func TenantScope(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID := ctx.Value(tenantKey{})
+		if tenantID == nil {
+			db.AddError(ErrMissingTenant)
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: tenantID})
+	}
+}
+
+// TenantInt64 获取 context 中的 int64 租户 ID
+func TenantInt64(ctx context.Context) (int64, error) {
+	switch v := ctx.Value(tenantKey{}).(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case uint:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case string:
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, ErrMissingTenant
+		}
+		return id, nil
+	default:
+		return 0, ErrMissingTenant
+	}
+}
+
+// TenantInt 获取 context 中的 int 租户 ID
+func TenantInt(ctx context.Context) (int, error) {
+	id, err := TenantInt64(ctx)
+	return int(id), err
+}
+
+// TenantString 获取 context 中的字符串租户 ID
+func TenantString(ctx context.Context) (string, error) {
+	switch v := ctx.Value(tenantKey{}).(type) {
+	case nil:
+		return "", ErrMissingTenant
+	case string:
+		if v == "" {
+			return "", ErrMissingTenant
+		}
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
 // IsNotFound 检查是否为未找到错误
 func IsNotFound(err error) bool {
-	return errors.Is(err, gorm.ErrRecordNotFound)
+	return errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrNotFound)
+}
+
+// 数据库错误对应的服务错误
+var (
+	// ErrNotFound 记录不存在
+	ErrNotFound = NewServiceError(404, "记录不存在")
+	// ErrDuplicateKey 唯一键冲突（MySQL 1062）
+	ErrDuplicateKey = NewServiceError(409, "数据已存在")
+	// ErrReferenced 数据被其他记录引用，无法删除或修改（MySQL 1451）
+	ErrReferenced = NewServiceError(409, "数据被其他记录引用，无法删除或修改")
+	// ErrReferenceMissing 引用的关联数据不存在（MySQL 1452）
+	ErrReferenceMissing = NewServiceError(422, "关联数据不存在")
+	// ErrForeignKeyViolated 违反外键约束但无法区分方向：gorm.Config 开启 TranslateError 时，
+	// 1451 与 1452 都被转换为 gorm.ErrForeignKeyViolated，原始错误码丢失
+	ErrForeignKeyViolated = NewServiceError(409, "违反外键约束")
+	// ErrInternal 未识别的内部错误，不向客户端暴露原始错误信息
+	ErrInternal = NewServiceError(500, "服务器内部错误")
+)
+
+// NewValidationError 创建参数校验错误
+func NewValidationError(message string) error {
+	return NewServiceError(422, message)
+}
+
+// TranslateError 将数据库错误转换为对应的 ServiceError，ServiceError 和无法识别的错误原样返回；
+// 枚举或集合字段的非法取值（包括未设置的非空字段）转换为 422 校验错误。
+// 区分 ErrReferenced 与 ErrReferenceMissing 依赖原始的 MySQL 错误码，gorm.Config 需保持 TranslateError 关闭；
+// 开启时外键错误只能返回 ErrForeignKeyViolated
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var serviceErr ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr
+	}
+	var invalidErr *{{.ModelName}}.InvalidValueError
+	if errors.As(err, &invalidErr) {
+		return NewValidationError(invalidErr.Error())
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1062:
+			return ErrDuplicateKey
+		case 1451:
+			return ErrReferenced
+		case 1452:
+			return ErrReferenceMissing
+		}
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrDuplicateKey
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrForeignKeyViolated
+	}
+	return err
+}
+
+// 过滤操作符
+const (
+	OpEq   = "eq"
+	OpIn   = "in"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpLike = "like"
+)
+
+// ColumnKind 字段类别，决定字段支持的过滤操作符及取值解析方式
+type ColumnKind int
+
+const (
+	KindOther ColumnKind = iota
+	KindString
+	KindInt
+	KindFloat
+	KindTime
+	KindBool
+)
+
+// kindOps 各字段类别允许的过滤操作符
+var kindOps = map[ColumnKind][]string{
+	KindString: {OpEq, OpIn, OpLike},
+	KindInt:    {OpEq, OpIn, OpGt, OpGte, OpLt, OpLte},
+	KindFloat:  {OpEq, OpIn, OpGt, OpGte, OpLt, OpLte},
+	KindTime:   {OpEq, OpGt, OpGte, OpLt, OpLte},
+	KindBool:   {OpEq},
+}
+
+// timeLayouts 时间过滤值支持的格式
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// ColumnSpec 字段白名单定义
+type ColumnSpec struct {
+	Kind     ColumnKind
+	Sortable bool
+}
+
+// Filter 过滤条件
+type Filter struct {
+	Column string
+	Op     string
+	Values []string
+}
+
+// SortField 排序字段
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// ListQuery 列表查询参数
+type ListQuery struct {
+	Page     int
+	PageSize int
+	Filters  []Filter
+	Sorts    []SortField
+	Fields   []string
+	// SkipTotal 为 true 时不统计总数
+	SkipTotal bool
+}
+
+// CursorQuery 游标分页查询参数
+type CursorQuery struct {
+	Cursor   string
+	PageSize int
+	Desc     bool
+	Filters  []Filter
+	Fields   []string
+	// WithTotal 为 true 时额外统计总数，大表上代价较高
+	WithTotal bool
+}
+
+// CursorPage 游标分页结果
+type CursorPage struct {
+	NextCursor string ` + "`json:\"next_cursor\"`" + `
+	Total      *int64 ` + "`json:\"total,omitempty\"`" + `
+}
+
+// Offset 计算分页偏移量
+func (q ListQuery) Offset() int {
+	if q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.Limit()
+}
+
+// Limit 获取每页数量
+func (q ListQuery) Limit() int {
+	if q.PageSize < 1 {
+		return 10
+	}
+	return q.PageSize
+}
+
+// ApplyFilters 按字段白名单应用过滤条件
+func ApplyFilters(db *gorm.DB, columns map[string]ColumnSpec, filters []Filter) (*gorm.DB, error) {
+	for _, f := range filters {
+		spec, ok := columns[f.Column]
+		if !ok {
+			return nil, NewServiceError(400, "不支持的过滤字段: "+f.Column)
+		}
+		if !allowOp(spec.Kind, f.Op) {
+			return nil, NewServiceError(400, fmt.Sprintf("字段 %s 不支持操作符 %s", f.Column, f.Op))
+		}
+		if len(f.Values) == 0 {
+			return nil, NewServiceError(400, "缺少过滤值: "+f.Column)
+		}
+
+		values := make([]interface{}, 0, len(f.Values))
+		for _, raw := range f.Values {
+			v, err := parseValue(spec.Kind, raw)
+			if err != nil {
+				return nil, NewServiceError(400, fmt.Sprintf("字段 %s 的过滤值无效: %s", f.Column, raw))
+			}
+			values = append(values, v)
+		}
+
+		column := clause.Column{Name: f.Column}
+		var expr clause.Expression
+		switch f.Op {
+		case OpEq:
+			expr = clause.Eq{Column: column, Value: values[0]}
+		case OpIn:
+			expr = clause.IN{Column: column, Values: values}
+		case OpGt:
+			expr = clause.Gt{Column: column, Value: values[0]}
+		case OpGte:
+			expr = clause.Gte{Column: column, Value: values[0]}
+		case OpLt:
+			expr = clause.Lt{Column: column, Value: values[0]}
+		case OpLte:
+			expr = clause.Lte{Column: column, Value: values[0]}
+		case OpLike:
+			expr = clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []interface{}{column, "%" + EscapeLike(f.Values[0]) + "%"}}
+		}
+		db = db.Where(expr)
+	}
+	return db, nil
+}
+
+// ApplySorts 按字段白名单应用排序，未指定排序时使用默认排序
+func ApplySorts(db *gorm.DB, columns map[string]ColumnSpec, sorts []SortField, defaults ...SortField) (*gorm.DB, error) {
+	if len(sorts) == 0 {
+		sorts = defaults
+	}
+	for _, s := range sorts {
+		spec, ok := columns[s.Column]
+		if !ok || !spec.Sortable {
+			return nil, NewServiceError(400, "不支持的排序字段: "+s.Column)
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column}, Desc: s.Desc})
+	}
+	return db, nil
+}
+
+// ApplyFields 按字段白名单应用字段投影
+func ApplyFields(db *gorm.DB, columns map[string]ColumnSpec, fields []string) (*gorm.DB, error) {
+	if len(fields) == 0 {
+		return db, nil
+	}
+	if err := checkColumns(columns, fields); err != nil {
+		return nil, err
+	}
+	return db.Select(fields), nil
+}
+
+// checkColumns 检查字段是否都在白名单中
+func checkColumns(columns map[string]ColumnSpec, names []string) error {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return NewServiceError(400, "不支持的字段: "+name)
+		}
+	}
+	return nil
+}
+
+// ApplyCursor 按游标键应用 keyset 条件与排序
+func ApplyCursor(db *gorm.DB, columns map[string]ColumnSpec, keys []string, q CursorQuery) (*gorm.DB, error) {
+	if q.Cursor != "" {
+		raw, err := decodeCursor(q.Cursor, len(keys))
+		if err != nil {
+			return nil, NewServiceError(400, "无效的游标")
+		}
+
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			if values[i], err = parseValue(columns[key].Kind, raw[i]); err != nil {
+				return nil, NewServiceError(400, "无效的游标")
+			}
+		}
+		db = db.Where(keysetExpr(keys, values, q.Desc))
+	}
+
+	for _, key := range keys {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: key}, Desc: q.Desc})
+	}
+	return db, nil
+}
+
+// EncodeCursor 将游标键的值编码为不透明游标
+func EncodeCursor(values ...interface{}) string {
+	raw := make([]string, len(values))
+	for i, v := range values {
+		switch val := v.(type) {
+		case time.Time:
+			raw[i] = val.Format(time.RFC3339Nano)
+		default:
+			raw[i] = fmt.Sprint(val)
+		}
+	}
+	data, _ := json.Marshal(raw)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解码游标
+func decodeCursor(cursor string, n int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var raw []string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) != n {
+		return nil, fmt.Errorf("cursor expects %d values, got %d", n, len(raw))
+	}
+	return raw, nil
+}
+
+// keysetExpr 构建 (k1 > v1) OR (k1 = v1 AND k2 > v2) ... 形式的 keyset 条件
+func keysetExpr(keys []string, values []interface{}, desc bool) clause.Expression {
+	ors := make([]clause.Expression, 0, len(keys))
+	for i, key := range keys {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: clause.Column{Name: keys[j]}, Value: values[j]})
+		}
+		if desc {
+			ands = append(ands, clause.Lt{Column: clause.Column{Name: key}, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: clause.Column{Name: key}, Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	// 单个 OR 条件会与前面的 WHERE 条件以 OR 连接，需直接返回
+	if len(ors) == 1 {
+		return ors[0]
+	}
+	return clause.Or(ors...)
+}
+
+// DefaultBatchSize 批量创建的默认批次大小
+const DefaultBatchSize = 100
+
+// EscapeLike 转义 LIKE 通配符，配合 ESCAPE '!' 使用
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// likeEscaper LIKE 通配符转义器
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// allowOp 检查字段类别是否支持该操作符
+func allowOp(kind ColumnKind, op string) bool {
+	for _, allowed := range kindOps[kind] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseValue 按字段类别解析过滤值
+func parseValue(kind ColumnKind, raw string) (interface{}, error) {
+	switch kind {
+	case KindInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case KindFloat:
+		return strconv.ParseFloat(raw, 64)
+	case KindBool:
+		return strconv.ParseBool(raw)
+	case KindTime:
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time: %s", raw)
+	default:
+		return raw, nil
+	}
 }
 `
 
-	file, err := os.Create(filepath.Join(g.config.ServiceOutput, "base.go"))
+	t, err := template.New("service_base").Parse(tmpl)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	_, err = file.WriteString(tmpl)
-	return err
+	return writeGoFile(filepath.Join(g.config.ServiceOutput, "base.go"), t, map[string]interface{}{
+		"ModelPackage": g.config.ModelImportPath,
+		"ModelName":    g.modelPackageName(),
+	})
+}
+
+// generateServiceContainer 生成 Service 容器文件，集中构造全部表的 Service
+func (g *Generator) generateServiceContainer(tables []TableInfo) error {
+	tmpl := `package services
+
+import (
+	"context"
+
+	mysqlx "{{.StoragePackage}}/mysql"
+	"gorm.io/gorm"
+)
+
+// Repos 绑定到同一连接（或事务）的全部服务
+type Repos struct {
+	{{- range .Services}}
+	{{.FieldName}} *{{.ServiceName}}
+	{{- end}}
+}
+
+// NewRepos 创建绑定到 db 的全部服务，db 为 nil 时使用默认数据库连接
+func NewRepos(db *gorm.DB) Repos {
+	return Repos{
+		{{- range .Services}}
+		{{.FieldName}}: New{{.ServiceName}}().WithTx(db),
+		{{- end}}
+	}
+}
+
+// Transaction 在事务中执行 fn，fn 返回错误或 panic 时回滚，否则提交
+func Transaction(ctx context.Context, fn func(tx Repos) error) error {
+	return mysqlx.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepos(tx))
+	})
+}
+`
+
+	t, err := template.New("service_container").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	var services []map[string]interface{}
+	for _, table := range tables {
+		services = append(services, map[string]interface{}{
+			"FieldName":   g.pluralName(table),
+			"ServiceName": g.modelName(table) + "Service",
+		})
+	}
+
+	return writeGoFile(filepath.Join(g.config.ServiceOutput, "services.go"), t, map[string]interface{}{
+		"StoragePackage": g.config.StorageImportPath,
+		"Services":       services,
+	})
 }
 
 // generateTableService 生成表 Service
@@ -90,40 +624,98 @@ func (g *Generator) generateTableService(table TableInfo) error {
 	tmpl := `package services
 
 import (
+	"context"
+
 	"{{.ModelPackage}}"
 	mysqlx "{{.StoragePackage}}/mysql"
 	"gorm.io/gorm"
+	{{- if .Upsert}}
+	"gorm.io/gorm/clause"
+	{{- end}}
 )
 
+// {{.ColumnsVarName}} {{.Comment}}字段白名单，用于列表过滤、排序与字段投影
+var {{.ColumnsVarName}} = map[string]ColumnSpec{
+	{{- range .ListColumns}}
+	"{{.DBName}}": {Kind: {{.Kind}}{{if .Sortable}}, Sortable: true{{end}}},
+	{{- end}}
+}
+{{- if and .PK .PK.Multiple}}
+
+// {{.PK.KeyType}} {{.Comment}}主键
+type {{.PK.KeyType}} struct {
+	{{- range .PK.Keys}}
+	{{.GoName}} {{.GoType}} ` + "`json:\"{{.DBName}}\"`" + `
+	{{- end}}
+}
+{{- end}}
+
 // {{.ServiceName}} {{.Comment}}服务
-type {{.ServiceName}} struct{}
+type {{.ServiceName}} struct {
+	db *gorm.DB
+}
 
 // New{{.ServiceName}} 创建{{.Comment}}服务实例
 func New{{.ServiceName}}() *{{.ServiceName}} {
 	return &{{.ServiceName}}{}
 }
 
+// WithTx 返回绑定到指定事务（或连接）的{{.Comment}}服务
+func (s *{{.ServiceName}}) WithTx(tx *gorm.DB) *{{.ServiceName}} {
+	return &{{.ServiceName}}{db: tx}
+}
+
+// conn 获取当前连接，未绑定事务时使用全局连接{{if .ShardFunc}}；按 context 中的分片键选择分表{{end}}
+func (s *{{.ServiceName}}) conn(ctx context.Context) *gorm.DB {
+	db := s.db
+	if db == nil {
+		db = mysqlx.DB
+	}
+	{{- if and .ShardFunc .Tenant}}
+	return db.WithContext(ctx).Scopes(ShardScope(ctx, {{.ShardFunc}}), TenantScope(ctx, "{{.Tenant.DBName}}"))
+	{{- else if .ShardFunc}}
+	return db.WithContext(ctx).Scopes(ShardScope(ctx, {{.ShardFunc}}))
+	{{- else if .Tenant}}
+	return db.WithContext(ctx).Scopes(TenantScope(ctx, "{{.Tenant.DBName}}"))
+	{{- else}}
+	return db.WithContext(ctx)
+	{{- end}}
+}
+{{- if not .ReadOnly}}
+
 // Create 创建{{.Comment}}
-func (s *{{.ServiceName}}) Create({{.ModelVarName}} *{{.ModelName}}) error {
-	return mysqlx.DB.Create({{.ModelVarName}}).Error
+func (s *{{.ServiceName}}) Create(ctx context.Context, {{.ModelVarName}} *{{.ModelType}}) error {
+	{{- if .Tenant}}
+	tenantID, err := {{.Tenant.Func}}(ctx)
+	if err != nil {
+		return err
+	}
+	{{.ModelVarName}}.{{.Tenant.GoName}} = tenantID
+
+	{{- end}}
+	return s.conn(ctx).Create({{.ModelVarName}}).Error
 }
+{{- end}}
+{{- if .PK}}
 
-// GetByID 根据ID获取{{.Comment}}
-func (s *{{.ServiceName}}) GetByID(id uint) (*{{.ModelName}}, error) {
-	var {{.ModelVarName}} {{.ModelName}}
-	err := mysqlx.DB.First(&{{.ModelVarName}}, id).Error
+// GetByID 根据主键获取{{.Comment}}
+func (s *{{.ServiceName}}) GetByID(ctx context.Context, {{.PK.Params}}) (*{{.ModelType}}, error) {
+	var {{.ModelVarName}} {{.ModelType}}
+	err := s.conn(ctx).Where("{{.PK.Where}}", {{.PK.Args}}).First(&{{.ModelVarName}}).Error
 	if err != nil {
 		return nil, err
 	}
 	return &{{.ModelVarName}}, nil
 }
+{{- end}}
 
 {{- if .HasUniqueFields}}
 {{- range .UniqueFields}}
+
 // GetBy{{.GoName}} 根据{{.Comment}}获取{{$.Comment}}
-func (s *{{$.ServiceName}}) GetBy{{.GoName}}({{.VarName}} {{.GoType}}) (*{{$.ModelName}}, error) {
-	var {{$.ModelVarName}} {{$.ModelName}}
-	err := mysqlx.DB.Where("{{.DBName}} = ?", {{.VarName}}).First(&{{$.ModelVarName}}).Error
+func (s *{{$.ServiceName}}) GetBy{{.GoName}}(ctx context.Context, {{.VarName}} {{.GoType}}) (*{{$.ModelType}}, error) {
+	var {{$.ModelVarName}} {{$.ModelType}}
+	err := s.conn(ctx).Where("{{.DBName}} = ?", {{.VarName}}).First(&{{$.ModelVarName}}).Error
 	if err != nil {
 		return nil, err
 	}
@@ -132,47 +724,282 @@ func (s *{{$.ServiceName}}) GetBy{{.GoName}}({{.VarName}} {{.GoType}}) (*{{$.Mod
 {{- end}}
 {{- end}}
 
+{{- if not .ReadOnly}}
+{{- if and .PK (or .Version .Tenant)}}
+
 // Update 更新{{.Comment}}
-func (s *{{.ServiceName}}) Update({{.ModelVarName}} *{{.ModelName}}) error {
-	return mysqlx.DB.Save({{.ModelVarName}}).Error
+{{- if .Tenant}}，仅能更新当前租户的数据{{end}}
+{{- if .Version}}，以 {{.Version.DBName}} 字段做乐观锁校验并自增，版本不一致时返回 ErrVersionConflict{{end}}
+func (s *{{.ServiceName}}) Update(ctx context.Context, {{.ModelVarName}} *{{.ModelType}}) error {
+	{{- if .Tenant}}
+	tenantID, err := {{.Tenant.Func}}(ctx)
+	if err != nil {
+		return err
+	}
+	{{.ModelVarName}}.{{.Tenant.GoName}} = tenantID
+	{{- end}}
+	{{- if .Version}}
+	version := {{.ModelVarName}}.{{.Version.GoName}}
+	{{.ModelVarName}}.{{.Version.GoName}} = version + 1
+	{{- end}}
+
+	result := s.conn(ctx).Model(&{{.ModelType}}{}).
+		Where("{{.PK.Where}}", {{range $i, $k := .PK.Keys}}{{if $i}}, {{end}}{{$.ModelVarName}}.{{$k.GoName}}{{end}}).
+		{{- if .Version}}
+		Where("` + "`{{.Version.DBName}}`" + ` = ?", version).
+		{{- end}}
+		Select("*").
+		Omit({{range $i, $k := .PK.Keys}}{{if $i}}, {{end}}"{{$k.DBName}}"{{end}}).
+		Updates({{.ModelVarName}})
+	{{- if .Version}}
+	if result.Error != nil {
+		{{.ModelVarName}}.{{.Version.GoName}} = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		{{.ModelVarName}}.{{.Version.GoName}} = version
+		return ErrVersionConflict
+	}
+	return nil
+	{{- else}}
+	// MySQL 在数据未变化时影响行数为 0，因此不以影响行数判断记录是否存在
+	return result.Error
+	{{- end}}
+}
+{{- else}}
+
+// Update 更新{{.Comment}}
+func (s *{{.ServiceName}}) Update(ctx context.Context, {{.ModelVarName}} *{{.ModelType}}) error {
+	return s.conn(ctx).Save({{.ModelVarName}}).Error
+}
+{{- end}}
+{{- if .PK}}
+
+// Delete 根据主键删除{{.Comment}}
+func (s *{{.ServiceName}}) Delete(ctx context.Context, {{.PK.Params}}) error {
+	return s.conn(ctx).Where("{{.PK.Where}}", {{.PK.Args}}).Delete(&{{.ModelType}}{}).Error
+}
+{{- end}}
+
+// CreateBatch 分批创建{{.Comment}}，batchSize 不大于 0 时使用 DefaultBatchSize
+func (s *{{.ServiceName}}) CreateBatch(ctx context.Context, {{.PluralVarName}} []{{.ModelType}}, batchSize int) error {
+	if len({{.PluralVarName}}) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	{{- if .Tenant}}
+	tenantID, err := {{.Tenant.Func}}(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range {{.PluralVarName}} {
+		{{.PluralVarName}}[i].{{.Tenant.GoName}} = tenantID
+	}
+	{{- end}}
+	return s.conn(ctx).CreateInBatches({{.PluralVarName}}, batchSize).Error
+}
+{{- if .PK}}
+
+// DeleteByIDs 根据主键批量删除{{.Comment}}
+func (s *{{.ServiceName}}) DeleteByIDs(ctx context.Context, ids []{{.PK.KeyType}}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	{{- if .PK.Multiple}}
+	rows := make([][]interface{}, len(ids))
+	for i, id := range ids {
+		rows[i] = []interface{}{ {{- range $i, $k := .PK.Keys}}{{if $i}}, {{end}}id.{{$k.GoName}}{{end -}} }
+	}
+	return s.conn(ctx).Where("({{.PK.Columns}}) IN ?", rows).Delete(&{{.ModelType}}{}).Error
+	{{- else}}
+	return s.conn(ctx).Where("{{.PK.Columns}} IN ?", ids).Delete(&{{.ModelType}}{}).Error
+	{{- end}}
 }
+{{- end}}
+
+// UpdateWhere 批量更新满足过滤条件的{{.Comment}}，返回受影响行数；过滤条件与更新字段均按白名单校验
+{{- if .Immutable}}，
+// 主键、租户与版本字段 ({{join .Immutable ", "}}) 不允许批量更新{{end}}
+func (s *{{.ServiceName}}) UpdateWhere(ctx context.Context, filters []Filter, values map[string]interface{}) (int64, error) {
+	if len(filters) == 0 {
+		return 0, NewServiceError(400, "批量更新必须指定过滤条件")
+	}
+	if len(values) == 0 {
+		return 0, NewServiceError(400, "批量更新必须指定更新字段")
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	if err := checkColumns({{.ColumnsVarName}}, names); err != nil {
+		return 0, err
+	}
+	{{- if .Immutable}}
+	for _, name := range names {
+		switch name {
+		case {{range $i, $c := .Immutable}}{{if $i}}, {{end}}"{{$c}}"{{end}}:
+			return 0, NewServiceError(400, "字段 "+name+" 不允许批量更新")
+		}
+	}
+	{{- end}}
+
+	{{- if .Version}}
 
-// Delete 删除{{.Comment}}
-func (s *{{.ServiceName}}) Delete(id uint) error {
-	return mysqlx.DB.Delete(&{{.ModelName}}{}, id).Error
+	// 批量更新同样递增版本号，使持有旧版本的单条更新失败
+	updates := make(map[string]interface{}, len(values)+1)
+	for name, value := range values {
+		updates[name] = value
+	}
+	updates["{{.Version.DBName}}"] = gorm.Expr("` + "`{{.Version.DBName}}`" + ` + 1")
+	values = updates
+	{{- end}}
+
+	query, err := ApplyFilters(s.conn(ctx).Model(&{{.ModelType}}{}), {{.ColumnsVarName}}, filters)
+	if err != nil {
+		return 0, err
+	}
+	result := query.Updates(values)
+	return result.RowsAffected, result.Error
+}
+{{- if .Upsert}}
+
+// Upsert 批量插入{{.Comment}}，按唯一键 ({{join .Upsert.Conflict ", "}}) 冲突时更新其余字段（MySQL 为 ON DUPLICATE KEY UPDATE）
+{{- if .Version}}，
+// 冲突时 {{.Version.DBName}} 在原值基础上自增，不使用传入的版本号{{end}}
+{{- if .Upsert.SoftDelete}}；
+// 与已软删除的记录冲突时只更新字段，记录保持删除状态，不会被恢复{{end}}
+func (s *{{.ServiceName}}) Upsert(ctx context.Context, {{.PluralVarName}} []{{.ModelType}}) error {
+	if len({{.PluralVarName}}) == 0 {
+		return nil
+	}
+	{{- if .Tenant}}
+	tenantID, err := {{.Tenant.Func}}(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range {{.PluralVarName}} {
+		{{.PluralVarName}}[i].{{.Tenant.GoName}} = tenantID
+	}
+	{{- end}}
+	{{- if .Version}}
+	updates := clause.AssignmentColumns([]string{ {{- range $i, $c := .Upsert.Updates}}{{if $i}}, {{end}}"{{$c}}"{{end -}} })
+	updates = append(updates, clause.Assignment{Column: clause.Column{Name: "{{.Version.DBName}}"}, Value: gorm.Expr("` + "`{{.Version.DBName}}`" + ` + 1")})
+	return s.conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{ {{- range $i, $c := .Upsert.Conflict}}{{if $i}}, {{end}}{Name: "{{$c}}"}{{end -}} },
+		DoUpdates: updates,
+	}).Create(&{{.PluralVarName}}).Error
+	{{- else}}
+	return s.conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{ {{- range $i, $c := .Upsert.Conflict}}{{if $i}}, {{end}}{Name: "{{$c}}"}{{end -}} },
+		DoUpdates: clause.AssignmentColumns([]string{ {{- range $i, $c := .Upsert.Updates}}{{if $i}}, {{end}}"{{$c}}"{{end -}} }),
+	}).Create(&{{.PluralVarName}}).Error
+	{{- end}}
 }
+{{- end}}
+{{- end}}
 
-// List 获取{{.Comment}}列表
-func (s *{{.ServiceName}}) List(page, pageSize int) ([]{{.ModelName}}, int64, error) {
-	var {{.ModelVarName}}s []{{.ModelName}}
+// List 获取{{.Comment}}列表，过滤、排序和字段均按白名单校验
+func (s *{{.ServiceName}}) List(ctx context.Context, q ListQuery) ([]{{.ModelType}}, int64, error) {
+	var {{.PluralVarName}} []{{.ModelType}}
 	var total int64
 
+	query, err := ApplyFilters(s.conn(ctx).Model(&{{.ModelType}}{}), {{.ColumnsVarName}}, q.Filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// 获取总数
-	err := mysqlx.DB.Model(&{{.ModelName}}{}).Count(&total).Error
+	if !q.SkipTotal {
+		err = query.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query, err = ApplySorts(query, {{.ColumnsVarName}}, q.Sorts{{range .DefaultSorts}}, SortField{Column: "{{.}}"}{{end}})
+	if err != nil {
+		return nil, 0, err
+	}
+	query, err = ApplyFields(query, {{.ColumnsVarName}}, q.Fields)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 获取分页数据
-	offset := (page - 1) * pageSize
-	err = mysqlx.DB.Offset(offset).Limit(pageSize).Find(&{{.ModelVarName}}s).Error
+	err = query.Offset(q.Offset()).Limit(q.Limit()).Find(&{{.PluralVarName}}).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return {{.ModelVarName}}s, total, nil
+	return {{.PluralVarName}}, total, nil
+}
+
+{{- if .CursorKeys}}
+
+// ListByCursor 按游标获取{{.Comment}}列表（keyset 分页），游标键为 {{range $i, $k := .CursorKeys}}{{if $i}}, {{end}}{{$k.DBName}}{{end}}
+func (s *{{.ServiceName}}) ListByCursor(ctx context.Context, q CursorQuery) ([]{{.ModelType}}, CursorPage, error) {
+	var {{.PluralVarName}} []{{.ModelType}}
+	var page CursorPage
+
+	query, err := ApplyFilters(s.conn(ctx).Model(&{{.ModelType}}{}), {{.ColumnsVarName}}, q.Filters)
+	if err != nil {
+		return nil, page, err
+	}
+
+	// 按需获取总数
+	if q.WithTotal {
+		var total int64
+		err = query.Count(&total).Error
+		if err != nil {
+			return nil, page, err
+		}
+		page.Total = &total
+	}
+
+	// 字段投影时必须包含游标键
+	fields := q.Fields
+	if len(fields) > 0 {
+		fields = append(append([]string{}, fields...){{range .CursorKeys}}, "{{.DBName}}"{{end}})
+	}
+	query, err = ApplyFields(query, {{.ColumnsVarName}}, fields)
+	if err != nil {
+		return nil, page, err
+	}
+	query, err = ApplyCursor(query, {{.ColumnsVarName}}, []string{ {{- range $i, $k := .CursorKeys}}{{if $i}}, {{end}}"{{$k.DBName}}"{{end -}} }, q)
+	if err != nil {
+		return nil, page, err
+	}
+
+	// 多取一条用于判断是否还有下一页
+	limit := ListQuery{PageSize: q.PageSize}.Limit()
+	err = query.Limit(limit + 1).Find(&{{.PluralVarName}}).Error
+	if err != nil {
+		return nil, page, err
+	}
+
+	if len({{.PluralVarName}}) > limit {
+		{{.PluralVarName}} = {{.PluralVarName}}[:limit]
+		last := {{.PluralVarName}}[limit-1]
+		page.NextCursor = EncodeCursor({{range $i, $k := .CursorKeys}}{{if $i}}, {{end}}last.{{$k.GoName}}{{end}})
+	}
+
+	return {{.PluralVarName}}, page, nil
 }
+{{- end}}
 
 {{- if .HasSearchFields}}
-// Search 搜索{{.Comment}}
-func (s *{{.ServiceName}}) Search(keyword string, page, pageSize int) ([]{{.ModelName}}, int64, error) {
-	var {{.ModelVarName}}s []{{.ModelName}}
+
+// Search 搜索{{.Comment}}，关键词匹配任一搜索字段即可
+func (s *{{.ServiceName}}) Search(ctx context.Context, keyword string, page, pageSize int) ([]{{.ModelType}}, int64, error) {
+	var {{.PluralVarName}} []{{.ModelType}}
 	var total int64
 
-	query := mysqlx.DB.Model(&{{.ModelName}}{})
-	{{- range .SearchFields}}
-	query = query.Where("{{.DBName}} LIKE ?", "%"+keyword+"%")
+	{{- if .SearchLike}}
+	pattern := "%" + EscapeLike(keyword) + "%"
 	{{- end}}
+	query := s.conn(ctx).Model(&{{.ModelType}}{}).Where("{{.SearchClause}}", {{.SearchArgs}})
 
 	// 获取总数
 	err := query.Count(&total).Error
@@ -182,72 +1009,95 @@ func (s *{{.ServiceName}}) Search(keyword string, page, pageSize int) ([]{{.Mode
 
 	// 获取分页数据
 	offset := (page - 1) * pageSize
-	err = query.Offset(offset).Limit(pageSize).Find(&{{.ModelVarName}}s).Error
+	err = query.Offset(offset).Limit(pageSize).Find(&{{.PluralVarName}}).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return {{.ModelVarName}}s, total, nil
+	return {{.PluralVarName}}, total, nil
 }
 {{- end}}
 `
 
-	t, err := template.New("service").Parse(tmpl)
+	t, err := template.New("service").Funcs(template.FuncMap{"join": strings.Join}).Parse(tmpl)
 	if err != nil {
 		return err
 	}
 
+	var pk map[string]interface{}
+	if keys := g.getPrimaryKeyFields(table); len(keys) > 0 {
+		pk = g.primaryKeyTemplateData(table, keys)
+	}
+
+	searchFields := g.getSearchFields(table)
+	searchClause, searchArgs, searchLike := g.buildSearchClause(table, searchFields)
+
+	version := g.getVersionColumn(table)
+	tenant := g.getTenantColumn(table)
+
+	// 视图只读，不生成写入方法；多租户表不生成 Upsert：
+	// ON DUPLICATE KEY UPDATE 不受租户条件限定，会覆盖其他租户主键或唯一键冲突的记录
+	var upsert map[string]interface{}
+	if !table.IsView && tenant == nil {
+		upsert = g.getUpsertColumns(table, version)
+	}
+
 	// 准备模板数据
 	data := map[string]interface{}{
 		"ModelPackage":    g.config.ModelImportPath,
 		"StoragePackage":  g.config.StorageImportPath,
-		"ServiceName":     g.toCamelCase(table.Name) + "Service",
-		"ModelName":       g.toCamelCase(table.Name),
-		"ModelVarName":    g.toLowerCamelCase(table.Name),
+		"ServiceName":     g.modelName(table) + "Service",
+		"ModelName":       g.modelName(table),
+		"ModelType":       g.modelPackageName() + "." + g.modelName(table),
+		"ModelVarName":    g.toVarName(g.modelName(table)),
+		"PluralVarName":   g.toVarName(g.pluralVarName(table)),
+		"ColumnsVarName":  g.toLowerCamelCase(g.modelName(table)) + "Columns",
 		"Comment":         table.Comment,
+		"ListColumns":     g.getListColumns(table),
+		"DefaultSorts":    table.PrimaryKeys,
+		"CursorKeys":      g.getCursorKeys(table),
+		"PK":              pk,
+		"Upsert":          upsert,
+		"ReadOnly":        table.IsView,
+		"Version":         version,
+		"Tenant":          tenant,
+		"Immutable":       g.getImmutableColumns(table, version, tenant),
+		"ShardFunc":       g.shardFunc(table),
 		"UniqueFields":    g.getUniqueFields(table.Columns),
-		"SearchFields":    g.getSearchFields(table.Columns),
 		"HasUniqueFields": len(g.getUniqueFields(table.Columns)) > 0,
-		"HasSearchFields": len(g.getSearchFields(table.Columns)) > 0,
+		"HasSearchFields": len(searchFields) > 0,
+		"SearchClause":    searchClause,
+		"SearchArgs":      searchArgs,
+		"SearchLike":      searchLike,
 	}
 
 	// 生成文件名
-	fileName := g.toSnakeCase(table.Name) + "_service.go"
+	fileName := g.fileName(table) + "_service.go"
 	filePath := filepath.Join(g.config.ServiceOutput, fileName)
 
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return t.Execute(file, data)
-}
-
-// toLowerCamelCase 转换为小驼峰命名
-func (g *Generator) toLowerCamelCase(s string) string {
-	camel := g.toCamelCase(s)
-	if len(camel) > 0 {
-		return strings.ToLower(camel[:1]) + camel[1:]
-	}
-	return camel
+	return writeGoFile(filePath, t, data)
 }
 
 // getUniqueFields 获取唯一字段
 func (g *Generator) getUniqueFields(columns []ColumnInfo) []map[string]interface{} {
 	var result []map[string]interface{}
 	for _, col := range columns {
+		// 枚举字段取值有限，不作为唯一字段
+		if col.Enum != nil {
+			continue
+		}
 		// 检查是否为唯一字段（通过字段名或注释判断）
 		if strings.Contains(strings.ToLower(col.Name), "username") ||
 			strings.Contains(strings.ToLower(col.Name), "email") ||
 			strings.Contains(strings.ToLower(col.Name), "phone") ||
 			strings.Contains(strings.ToLower(col.Comment), "唯一") {
 			result = append(result, map[string]interface{}{
-				"GoName":  g.toCamelCase(col.Name),
-				"GoType":  col.GoType,
-				"VarName": g.toLowerCamelCase(col.Name),
-				"DBName":  col.Name,
-				"Comment": col.Comment,
+				"GoName":    g.columnGoName(col),
+				"GoType":    col.GoType,
+				"VarName":   g.toVarName(g.columnGoName(col)),
+				"DBName":    col.Name,
+				"Comment":   col.Comment,
+				"ZeroValue": g.getZeroValue(col.GoType),
 			})
 		}
 	}
@@ -255,15 +1105,283 @@ func (g *Generator) getUniqueFields(columns []ColumnInfo) []map[string]interface
 }
 
 // getSearchFields 获取搜索字段
-func (g *Generator) getSearchFields(columns []ColumnInfo) []map[string]interface{} {
+//
+// 优先使用配置的搜索字段；未配置时使用全文索引字段，否则使用带索引的字符串字段，
+// 都没有时回退到全部 char/varchar 字段。
+func (g *Generator) getSearchFields(table TableInfo) []string {
+	if configured, ok := g.config.SearchColumns[table.Name]; ok {
+		var result []string
+		for _, name := range configured {
+			if _, ok := findColumn(table.Columns, name); !ok {
+				log.Printf("警告: 表 %s 不存在搜索字段 %s，已忽略", table.Name, name)
+				continue
+			}
+			result = append(result, name)
+		}
+		return result
+	}
+
+	var fulltext, indexed, chars []string
+	for _, idx := range table.Indexes {
+		if idx.Type == "FULLTEXT" {
+			fulltext = append(fulltext, idx.Columns...)
+		}
+	}
+	for _, col := range table.Columns {
+		if col.GoType != "string" || !g.isCharType(col.Type) {
+			continue
+		}
+		chars = append(chars, col.Name)
+		if g.isIndexed(table, col.Name) {
+			indexed = append(indexed, col.Name)
+		}
+	}
+
+	switch {
+	case len(fulltext) > 0:
+		return fulltext
+	case len(indexed) > 0:
+		return indexed
+	default:
+		return chars
+	}
+}
+
+// buildSearchClause 构建搜索条件：全文索引完整覆盖的字段使用 MATCH ... AGAINST，
+// 其余字段使用转义后的 LIKE，各条件之间以 OR 连接
+func (g *Generator) buildSearchClause(table TableInfo, fields []string) (string, string, bool) {
+	covered := make(map[string]bool)
+	var parts, args []string
+	for _, idx := range table.Indexes {
+		if idx.Type != "FULLTEXT" || !containsAll(fields, idx.Columns) {
+			continue
+		}
+		quoted := make([]string, len(idx.Columns))
+		for i, name := range idx.Columns {
+			quoted[i] = "`" + name + "`"
+			covered[name] = true
+		}
+		parts = append(parts, fmt.Sprintf("MATCH (%s) AGAINST (?)", strings.Join(quoted, ", ")))
+		args = append(args, "keyword")
+	}
+
+	like := false
+	for _, name := range fields {
+		if covered[name] {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("`%s` LIKE ? ESCAPE '!'", name))
+		args = append(args, "pattern")
+		like = true
+	}
+
+	if len(parts) == 0 {
+		return "", "", false
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", strings.Join(args, ", "), like
+}
+
+// isCharType 判断是否为 char/varchar 类型
+func (g *Generator) isCharType(dbType string) bool {
+	dbType = strings.ToLower(dbType)
+	return strings.Contains(dbType, "char")
+}
+
+// isIndexed 判断字段是否为某个索引的前导列
+func (g *Generator) isIndexed(table TableInfo, column string) bool {
+	for _, idx := range table.Indexes {
+		if len(idx.Columns) > 0 && idx.Columns[0] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// getListColumns 获取列表字段白名单：字段类别决定过滤操作符，索引前导列与配置字段可排序
+func (g *Generator) getListColumns(table TableInfo) []map[string]interface{} {
+	sortable := make(map[string]bool)
+	for _, idx := range table.Indexes {
+		if len(idx.Columns) > 0 {
+			sortable[idx.Columns[0]] = true
+		}
+	}
+	for _, pk := range table.PrimaryKeys {
+		sortable[pk] = true
+	}
+	for _, name := range g.config.SortableColumns[table.Name] {
+		sortable[name] = true
+	}
+
 	var result []map[string]interface{}
-	for _, col := range columns {
-		// 字符串类型字段可用于搜索
-		if col.GoType == "string" || strings.Contains(col.GoType, "string") {
-			result = append(result, map[string]interface{}{
-				"DBName": col.Name,
-			})
+	for _, col := range table.Columns {
+		result = append(result, map[string]interface{}{
+			"DBName":   col.Name,
+			"Kind":     g.getColumnKind(baseGoType(col)),
+			"Sortable": sortable[col.Name],
+		})
+	}
+	return result
+}
+
+// getCursorKeys 获取游标分页的键：配置的游标字段（若有）加主键，保证排序唯一
+func (g *Generator) getCursorKeys(table TableInfo) []map[string]interface{} {
+	if g.config.Pagination != PaginationCursor {
+		return nil
+	}
+	if len(table.PrimaryKeys) == 0 {
+		log.Printf("警告: 表 %s 没有主键，不生成游标分页", table.Name)
+		return nil
+	}
+
+	names := table.PrimaryKeys
+	if column := g.config.CursorColumns[table.Name]; column != "" && !contains(table.PrimaryKeys, column) {
+		names = append([]string{column}, table.PrimaryKeys...)
+	}
+
+	var result []map[string]interface{}
+	for _, name := range names {
+		col, ok := findColumn(table.Columns, name)
+		if !ok {
+			log.Printf("警告: 表 %s 不存在游标字段 %s，不生成游标分页", table.Name, name)
+			return nil
 		}
+		if strings.HasPrefix(col.GoType, "*") {
+			log.Printf("警告: 表 %s 的游标字段 %s 可为空，不生成游标分页", table.Name, name)
+			return nil
+		}
+		result = append(result, map[string]interface{}{
+			"DBName": col.Name,
+			"GoName": g.fieldName(table, col.Name),
+		})
 	}
 	return result
 }
+
+// getUpsertColumns 获取 Upsert 的冲突键与更新字段：优先使用第一个非主键唯一索引，否则使用主键；
+// 版本字段不在更新字段中，由模板生成自增表达式；deleted_at 不更新，避免冲突时恢复已软删除的记录
+func (g *Generator) getUpsertColumns(table TableInfo, version map[string]interface{}) map[string]interface{} {
+	conflict := table.PrimaryKeys
+	for _, idx := range table.Indexes {
+		if idx.Unique && idx.Name != "PRIMARY" {
+			conflict = idx.Columns
+			break
+		}
+	}
+	if len(conflict) == 0 {
+		return nil
+	}
+
+	var updates []string
+	softDelete := false
+	for _, col := range table.Columns {
+		if col.Name == "deleted_at" {
+			softDelete = true
+			continue
+		}
+		if col.IsPrimaryKey || contains(conflict, col.Name) || col.Name == "created_at" || (version != nil && col.Name == version["DBName"]) {
+			continue
+		}
+		updates = append(updates, col.Name)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"Conflict":   conflict,
+		"Updates":    updates,
+		"SoftDelete": softDelete,
+	}
+}
+
+// getVersionColumn 获取乐观锁版本字段：取配置中第一个存在于表中的非空整型字段，且表需有主键
+func (g *Generator) getVersionColumn(table TableInfo) map[string]interface{} {
+	if len(g.getPrimaryKeyFields(table)) == 0 {
+		return nil
+	}
+	for _, name := range g.config.VersionColumns {
+		col, ok := findColumn(table.Columns, name)
+		if !ok {
+			continue
+		}
+		if col.GoType != "int" && col.GoType != "int64" {
+			log.Printf("警告: 表 %s 的版本字段 %s 不是非空整型，不启用乐观锁", table.Name, name)
+			return nil
+		}
+		return map[string]interface{}{
+			"DBName": col.Name,
+			"GoName": g.fieldName(table, col.Name),
+		}
+	}
+	return nil
+}
+
+// tenantFuncs 租户字段 Go 类型对应的租户 ID 获取函数
+var tenantFuncs = map[string]string{
+	"int":    "TenantInt",
+	"int64":  "TenantInt64",
+	"string": "TenantString",
+}
+
+// getTenantColumn 获取多租户字段：表包含配置的租户字段时返回，字段类型已由 checkTenantColumns 校验
+func (g *Generator) getTenantColumn(table TableInfo) map[string]interface{} {
+	if g.config.TenantColumn == "" {
+		return nil
+	}
+	col, ok := findColumn(table.Columns, g.config.TenantColumn)
+	if !ok {
+		return nil
+	}
+	return map[string]interface{}{
+		"DBName": col.Name,
+		"GoName": g.fieldName(table, col.Name),
+		"Func":   tenantFuncs[col.GoType],
+	}
+}
+
+// checkTenantColumns 校验各表租户字段的类型；类型不受支持时返回错误，
+// 避免生成不限定租户的 Service 而使该表的数据对所有租户可见
+func (g *Generator) checkTenantColumns(tables []TableInfo) error {
+	if g.config.TenantColumn == "" {
+		return nil
+	}
+	for _, table := range tables {
+		col, ok := findColumn(table.Columns, g.config.TenantColumn)
+		if !ok {
+			continue
+		}
+		if _, ok := tenantFuncs[col.GoType]; !ok {
+			return fmt.Errorf("表 %s 的租户字段 %s 类型 %s 不受支持，租户字段须为非空的 int、int64 或 string", table.Name, col.Name, col.GoType)
+		}
+	}
+	return nil
+}
+
+// getImmutableColumns 获取不允许批量更新的字段：主键、租户字段与版本字段
+func (g *Generator) getImmutableColumns(table TableInfo, version, tenant map[string]interface{}) []string {
+	columns := append([]string{}, table.PrimaryKeys...)
+	for _, extra := range []map[string]interface{}{tenant, version} {
+		if extra != nil && !contains(columns, extra["DBName"].(string)) {
+			columns = append(columns, extra["DBName"].(string))
+		}
+	}
+	return columns
+}
+
+// getColumnKind 根据 Go 类型获取生成代码中的字段类别常量
+func (g *Generator) getColumnKind(goType string) string {
+	switch strings.TrimPrefix(goType, "*") {
+	case "string":
+		return "KindString"
+	case "int", "int64":
+		return "KindInt"
+	case "float64":
+		return "KindFloat"
+	case "time.Time":
+		return "KindTime"
+	case "bool":
+		return "KindBool"
+	default:
+		return "KindOther"
+	}
+}
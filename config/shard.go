@@ -0,0 +1,235 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// shardRule 编译后的分表规则，正则的命名分组 name（没有时为第一个分组）捕获逻辑表名
+type shardRule struct {
+	re    *regexp.Regexp
+	group int
+}
+
+// compileShardRules 编译分表规则，正则无效或没有捕获分组时返回错误
+func compileShardRules(patterns []string) ([]shardRule, error) {
+	var rules []shardRule
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("无效的分表规则 %s: %w", pattern, err)
+		}
+		if re.NumSubexp() == 0 {
+			return nil, fmt.Errorf("分表规则 %s 缺少捕获逻辑表名的分组", pattern)
+		}
+		group := re.SubexpIndex("name")
+		if group < 0 {
+			group = 1
+		}
+		rules = append(rules, shardRule{re: re, group: group})
+	}
+	return rules, nil
+}
+
+// logicalName 返回分表对应的逻辑表名，不匹配任何规则时返回空字符串
+func logicalName(rules []shardRule, table string) string {
+	for _, rule := range rules {
+		if m := rule.re.FindStringSubmatch(table); m != nil && m[rule.group] != "" {
+			return m[rule.group]
+		}
+	}
+	return ""
+}
+
+// collapseShards 按分表规则将分表合并为一个逻辑表：逻辑表使用排序后第一个分表的结构，
+// Shards 记录全部分表名，其他表指向分表的外键改为指向逻辑表。
+// 结构与第一个分表不一致的分表输出警告，仍保留在 Shards 中以免改变分片键到分表的映射
+func (g *Generator) collapseShards(tables []TableInfo) ([]TableInfo, error) {
+	rules, err := compileShardRules(g.config.ShardPatterns)
+	if err != nil || len(rules) == 0 {
+		return tables, err
+	}
+
+	groups := map[string][]TableInfo{}
+	positions := map[string]int{}
+	logical := map[string]string{}
+	names := map[string]bool{}
+	var result []TableInfo
+	for _, table := range tables {
+		names[table.Name] = true
+		name := logicalName(rules, table.Name)
+		if name == "" {
+			result = append(result, table)
+			continue
+		}
+		if _, ok := positions[name]; !ok {
+			// 逻辑表占据第一个分表的位置，内容在分组完成后填充
+			positions[name] = len(result)
+			result = append(result, TableInfo{Name: name})
+		}
+		groups[name] = append(groups[name], table)
+		logical[table.Name] = name
+	}
+
+	for i := range result {
+		name := result[i].Name
+		if positions[name] != i || len(groups[name]) == 0 {
+			continue
+		}
+		if names[name] {
+			log.Printf("警告: 分表的逻辑表名 %s 与已有的表同名", name)
+		}
+		shards := groups[name]
+		sort.Slice(shards, func(a, b int) bool { return naturalLess(shards[a].Name, shards[b].Name) })
+		merged := shards[0]
+		merged.Name = name
+		merged.Shards = make([]string, len(shards))
+		for j, shard := range shards {
+			merged.Shards[j] = shard.Name
+			if j > 0 {
+				if diff := diffColumns(shards[0].Columns, shard.Columns); diff != "" {
+					log.Printf("警告: 分表 %s 的结构与 %s 不一致: %s", shard.Name, shards[0].Name, diff)
+				}
+			}
+		}
+		if !contiguousShards(merged.Shards) {
+			log.Printf("警告: 逻辑表 %s 的分表后缀不是从 0 开始的连续编号，不生成默认的分表函数，需在初始化时设置", name)
+		}
+		fmt.Printf("合并分表 %s（%d 张）为逻辑表 %s\n", merged.Shards[0], len(shards), merged.Name)
+		result[i] = merged
+	}
+
+	// 外键引用分表时改为引用逻辑表
+	for i := range result {
+		fks := make([]ForeignKeyInfo, len(result[i].ForeignKeys))
+		for j, fk := range result[i].ForeignKeys {
+			if name, ok := logical[fk.RefTable]; ok {
+				fk.RefTable = name
+			}
+			fks[j] = fk
+		}
+		result[i].ForeignKeys = fks
+	}
+	return result, nil
+}
+
+// diffColumns 比较两个分表的列（名称、类型与枚举取值、可空、主键、自增），返回差异描述，一致时返回空字符串
+func diffColumns(want, got []ColumnInfo) string {
+	gotByName := make(map[string]ColumnInfo, len(got))
+	for _, col := range got {
+		gotByName[col.Name] = col
+	}
+	var missing, changed, extra []string
+	seen := make(map[string]bool, len(want))
+	for _, w := range want {
+		seen[w.Name] = true
+		c, ok := gotByName[w.Name]
+		if !ok {
+			missing = append(missing, w.Name)
+			continue
+		}
+		if columnSignature(c) != columnSignature(w) {
+			changed = append(changed, fmt.Sprintf("%s（%s，应为 %s）", w.Name, columnSignature(c), columnSignature(w)))
+		}
+	}
+	for _, c := range got {
+		if !seen[c.Name] {
+			extra = append(extra, c.Name)
+		}
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "缺少列 "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, "多出列 "+strings.Join(extra, ", "))
+	}
+	if len(changed) > 0 {
+		parts = append(parts, "列定义不同 "+strings.Join(changed, ", "))
+	}
+	return strings.Join(parts, "；")
+}
+
+// columnSignature 描述列的类型（enum/set 含取值）、可空、主键与自增属性，用于分表差异报告
+func columnSignature(col ColumnInfo) string {
+	typ := col.Type
+	if len(col.EnumValues) > 0 {
+		typ += "('" + strings.Join(col.EnumValues, "','") + "')"
+	}
+	parts := []string{typ}
+	if col.IsNullable {
+		parts = append(parts, "可空")
+	}
+	if col.IsPrimaryKey {
+		parts = append(parts, "主键")
+	}
+	if col.IsAutoIncr {
+		parts = append(parts, "自增")
+	}
+	return strings.Join(parts, " ")
+}
+
+// contiguousShards 判断按自然顺序排序的分表名的数字后缀是否依次为 0、1、2……（允许前导零，如 order_00）。
+// 只有这种情况下按分表数取模的结果才与分表后缀一致，可以生成默认的分表函数
+func contiguousShards(shards []string) bool {
+	for i, name := range shards {
+		j := len(name)
+		for j > 0 && name[j-1] >= '0' && name[j-1] <= '9' {
+			j--
+		}
+		n, err := strconv.Atoi(name[j:])
+		if err != nil || n != i {
+			return false
+		}
+	}
+	return true
+}
+
+// naturalLess 按自然顺序比较表名，数字部分按数值比较：order_2 排在 order_10 之前
+func naturalLess(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si, sj := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			na := strings.TrimLeft(string(ra[si:i]), "0")
+			nb := strings.TrimLeft(string(rb[sj:j]), "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			continue
+		}
+		if ra[i] != rb[j] {
+			return ra[i] < rb[j]
+		}
+		i++
+		j++
+	}
+	return len(ra)-i < len(rb)-j
+}
+
+// shardFunc 返回分表模型的分表函数表达式，如 models.OrderShardFunc，非分表返回空字符串
+func (g *Generator) shardFunc(table TableInfo) string {
+	if len(table.Shards) == 0 {
+		return ""
+	}
+	return g.modelPackageName() + "." + g.modelName(table) + "ShardFunc"
+}
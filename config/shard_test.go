@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCollapseShards(t *testing.T) {
+	g := NewGenerator(&Config{ShardPatterns: []string{`^(order)_\d+$`, `^log_(?P<name>\w+)_\d{6}$`}})
+	columns := []ColumnInfo{{Name: "id", Type: "bigint", IsPrimaryKey: true}, {Name: "amount", Type: "decimal"}}
+	tables, err := g.collapseShards([]TableInfo{
+		{Name: "users"},
+		{Name: "order_10", Columns: columns},
+		{Name: "order_2", Columns: columns},
+		{Name: "log_access_202401"},
+		{Name: "payments", ForeignKeys: []ForeignKeyInfo{{Column: "order_id", RefTable: "order_2", RefColumn: "id"}}},
+		{Name: "order_0", Columns: columns},
+		{Name: "log_access_202402"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name   string
+		shards []string
+	}{
+		{"users", nil},
+		{"order", []string{"order_0", "order_2", "order_10"}},
+		{"access", []string{"log_access_202401", "log_access_202402"}},
+		{"payments", nil},
+	}
+	if len(tables) != len(want) {
+		t.Fatalf("合并后有 %d 张表, 期望 %d", len(tables), len(want))
+	}
+	for i, w := range want {
+		if tables[i].Name != w.name || !reflect.DeepEqual(tables[i].Shards, w.shards) {
+			t.Errorf("第 %d 张表 = %s %v, 期望 %s %v", i, tables[i].Name, tables[i].Shards, w.name, w.shards)
+		}
+	}
+	if !reflect.DeepEqual(tables[1].Columns, columns) {
+		t.Errorf("逻辑表的列 = %v, 期望使用第一个分表的列", tables[1].Columns)
+	}
+	if got := tables[3].ForeignKeys[0].RefTable; got != "order" {
+		t.Errorf("引用分表的外键 RefTable = %q, 期望 order", got)
+	}
+}
+
+func TestCollapseShardsInvalid(t *testing.T) {
+	for _, pattern := range []string{`^order_\d+$`, `^(order_\d+`} {
+		g := NewGenerator(&Config{ShardPatterns: []string{pattern}})
+		if _, err := g.collapseShards([]TableInfo{{Name: "order_0"}}); err == nil {
+			t.Errorf("分表规则 %q 应返回错误", pattern)
+		}
+	}
+}
+
+func TestDiffColumns(t *testing.T) {
+	want := []ColumnInfo{
+		{Name: "id", Type: "bigint", IsPrimaryKey: true, IsAutoIncr: true},
+		{Name: "amount", Type: "decimal"},
+		{Name: "note", Type: "varchar", IsNullable: true},
+	}
+	if diff := diffColumns(want, want); diff != "" {
+		t.Errorf("相同结构的差异 = %q, 期望为空", diff)
+	}
+
+	got := []ColumnInfo{
+		{Name: "id", Type: "int", IsPrimaryKey: true, IsAutoIncr: true},
+		{Name: "amount", Type: "decimal"},
+		{Name: "extra", Type: "int"},
+	}
+	expected := "缺少列 note；多出列 extra；列定义不同 id（int 主键 自增，应为 bigint 主键 自增）"
+	if diff := diffColumns(want, got); diff != expected {
+		t.Errorf("差异 = %q, 期望 %q", diff, expected)
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{"order_10", "order_2", "order_01", "order_1a", "order", "order_0", "order_b", "order_a"}
+	sort.Slice(names, func(i, j int) bool { return naturalLess(names[i], names[j]) })
+	want := []string{"order", "order_0", "order_01", "order_1a", "order_2", "order_10", "order_a", "order_b"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("排序结果 = %v, 期望 %v", names, want)
+	}
+}
+
+func TestContiguousShards(t *testing.T) {
+	tests := []struct {
+		shards []string
+		want   bool
+	}{
+		{[]string{"orders_0", "orders_1", "orders_2"}, true},
+		{[]string{"order_00", "order_01", "order_02", "order_03"}, true},
+		{[]string{"orders_0", "orders_2", "orders_10"}, false},
+		{[]string{"orders_1", "orders_2"}, false},
+		{[]string{"log_access_202401", "log_access_202402"}, false},
+		{[]string{"orders_a", "orders_b"}, false},
+	}
+	for _, tt := range tests {
+		if got := contiguousShards(tt.shards); got != tt.want {
+			t.Errorf("contiguousShards(%v) = %v, 期望 %v", tt.shards, got, tt.want)
+		}
+	}
+}
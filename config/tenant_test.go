@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckTenantColumns(t *testing.T) {
+	g := NewGenerator(&Config{TenantColumn: "tenant_id"})
+	valid := TableInfo{Name: "projects", Columns: []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+		testColumn(g, "tenant_id", "bigint", false, false, false, ""),
+	}}
+	plain := TableInfo{Name: "logs", Columns: []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+	}}
+	if err := g.checkTenantColumns([]TableInfo{valid, plain}); err != nil {
+		t.Fatalf("checkTenantColumns 返回 %v, 期望 nil", err)
+	}
+
+	nullable := TableInfo{Name: "teams", Columns: []ColumnInfo{
+		testColumn(g, "id", "bigint", false, true, true, ""),
+		testColumn(g, "tenant_id", "bigint", true, false, false, ""),
+	}}
+	if err := g.checkTenantColumns([]TableInfo{valid, nullable}); err == nil {
+		t.Error("可空租户字段应返回错误，而不是生成不限定租户的 Service")
+	}
+}
+
+func TestGetImmutableColumns(t *testing.T) {
+	g := NewGenerator(&Config{TenantColumn: "tenant_id", VersionColumns: []string{"version"}})
+	table := TableInfo{Name: "order_items", PrimaryKeys: []string{"tenant_id", "id"}, Columns: []ColumnInfo{
+		testColumn(g, "tenant_id", "bigint", false, true, false, ""),
+		testColumn(g, "id", "bigint", false, true, false, ""),
+		testColumn(g, "version", "int", false, false, false, ""),
+	}}
+	got := g.getImmutableColumns(table, g.getVersionColumn(table), g.getTenantColumn(table))
+	if want := []string{"tenant_id", "id", "version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getImmutableColumns = %v, 期望 %v", got, want)
+	}
+}
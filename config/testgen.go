@@ -0,0 +1,1021 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// generateServiceTestBase 生成 Service 测试公共文件
+func (g *Generator) generateServiceTestBase() error {
+	tmpl := `package services
+
+import (
+	"strconv"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB 打开内存 SQLite 数据库并迁移模型，测试结束时自动关闭
+func openTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{TranslateError: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取数据库连接失败: %v", err)
+	}
+	// 内存数据库的每个连接相互独立，限制为单连接以共享同一数据库
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("迁移模型失败: %v", err)
+	}
+	return db
+}
+
+// ptr 返回值的指针，用于可空字段的测试数据
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// testString 生成带序号的字符串测试数据
+func testString(prefix string, n int) string {
+	return prefix + strconv.Itoa(n)
+}
+`
+
+	return writeGoFile(filepath.Join(g.config.ServiceOutput, "services_test.go"), template.Must(template.New("service_test_base").Parse(tmpl)), nil)
+}
+
+// generateTableServiceTest 生成表 Service 的测试：在内存 SQLite 中验证增删改查、列表、搜索与唯一字段查询
+func (g *Generator) generateTableServiceTest(table TableInfo) error {
+	tmpl := `package services
+
+import (
+	"context"
+	{{- if .NeedErrors}}
+	"errors"
+	{{- end}}
+	"testing"
+	{{- if .NeedTime}}
+	"time"
+	{{- end}}
+
+	"{{.ModelPackage}}"
+)
+
+// new{{.ModelName}}TestService 创建使用内存 SQLite 的{{.Comment}}服务
+func new{{.ModelName}}TestService(t *testing.T) (*{{.ServiceName}}, context.Context) {
+	t.Helper()
+	db := openTestDB(t, &{{.ModelType}}{})
+	{{- if .ShardFunc}}
+	{{- if .TestShard}}
+	// 分表后缀不连续，没有默认分表函数：测试中固定使用第一张分表
+	{{.ShardFunc}} = func(int64) string { return "{{.TestShard}}" }
+	t.Cleanup(func() { {{.ShardFunc}} = nil })
+	{{- end}}
+	// 分表：在分片键 0 对应的分表中建表，测试数据均写入该分表
+	if err := db.Table({{.ShardFunc}}(0)).AutoMigrate(&{{.ModelType}}{}); err != nil {
+		t.Fatalf("迁移分表失败: %v", err)
+	}
+	ctx := WithShardKey(context.Background(), 0)
+	{{- if .Tenant}}
+	return New{{.ServiceName}}().WithTx(db), WithTenant(ctx, {{.Tenant.Value}})
+	{{- else}}
+	return New{{.ServiceName}}().WithTx(db), ctx
+	{{- end}}
+	{{- else if .Tenant}}
+	return New{{.ServiceName}}().WithTx(db), WithTenant(context.Background(), {{.Tenant.Value}})
+	{{- else}}
+	return New{{.ServiceName}}().WithTx(db), context.Background()
+	{{- end}}
+}
+
+// {{.FixtureFunc}} 按序号生成{{.Comment}}测试数据，不同序号的唯一字段与主键互不相同
+func {{.FixtureFunc}}(n int) {{.ModelType}} {
+	return {{.ModelType}}{
+		{{- range .Fixtures}}
+		{{.GoName}}: {{.Value}},
+		{{- end}}
+	}
+}
+
+// create{{.ModelName}}Fixture 创建序号为 n 的{{.Comment}}测试数据{{if .ReadOnly}}，视图的 Service 只读，直接写入测试库{{end}}
+func create{{.ModelName}}Fixture(t *testing.T, svc *{{.ServiceName}}, ctx context.Context, n int) *{{.ModelType}} {
+	t.Helper()
+	m := {{.FixtureFunc}}(n)
+	{{- if .ReadOnly}}
+	{{- if .Tenant}}
+	tenantID, err := {{.Tenant.Func}}(ctx)
+	if err != nil {
+		t.Fatalf("获取租户失败: %v", err)
+	}
+	m.{{.Tenant.GoName}} = tenantID
+	{{- end}}
+	if err := svc.conn(ctx).Create(&m).Error; err != nil {
+		t.Fatalf("Create 失败: %v", err)
+	}
+	{{- else}}
+	if err := svc.Create(ctx, &m); err != nil {
+		t.Fatalf("Create 失败: %v", err)
+	}
+	{{- end}}
+	return &m
+}
+{{- if .PK}}
+
+func Test{{.ModelName}}CreateAndGet(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+
+	got, err := svc.GetByID(ctx, {{.KeyArgs}})
+	if err != nil {
+		t.Fatalf("GetByID 失败: %v", err)
+	}
+	{{- if .Check}}
+	if got.{{.Check.GoName}} != created.{{.Check.GoName}} {
+		t.Errorf("{{.Check.GoName}} = %v, 期望 %v", got.{{.Check.GoName}}, created.{{.Check.GoName}})
+	}
+	{{- else}}
+	_ = got
+	{{- end}}
+}
+{{- if not .ReadOnly}}
+
+func Test{{.ModelName}}Update(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+	{{- if .Version}}
+	stale := *created
+	{{- end}}
+
+	{{- if .Check}}
+	created.{{.Check.GoName}} = {{.Check.Updated}}
+	{{- end}}
+	if err := svc.Update(ctx, created); err != nil {
+		t.Fatalf("Update 失败: %v", err)
+	}
+
+	got, err := svc.GetByID(ctx, {{.KeyArgs}})
+	if err != nil {
+		t.Fatalf("GetByID 失败: %v", err)
+	}
+	{{- if not (or .Check .Version)}}
+	_ = got
+	{{- end}}
+	{{- if .Check}}
+	if got.{{.Check.GoName}} != {{.Check.Updated}} {
+		t.Errorf("{{.Check.GoName}} = %v, 期望 %v", got.{{.Check.GoName}}, {{.Check.Updated}})
+	}
+	{{- end}}
+	{{- if .Version}}
+	if got.{{.Version.GoName}} != stale.{{.Version.GoName}}+1 {
+		t.Errorf("{{.Version.GoName}} = %v, 期望 %v", got.{{.Version.GoName}}, stale.{{.Version.GoName}}+1)
+	}
+
+	// 使用旧版本号更新应返回版本冲突
+	if err := svc.Update(ctx, &stale); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("旧版本 Update 返回 %v, 期望 ErrVersionConflict", err)
+	}
+	{{- end}}
+}
+
+func Test{{.ModelName}}Delete(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+
+	if err := svc.Delete(ctx, {{.KeyArgs}}); err != nil {
+		t.Fatalf("Delete 失败: %v", err)
+	}
+	if _, err := svc.GetByID(ctx, {{.KeyArgs}}); !IsNotFound(err) {
+		t.Errorf("删除后 GetByID 返回 %v, 期望记录不存在", err)
+	}
+}
+
+func Test{{.ModelName}}UpdateWhereImmutable(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	filters := []Filter{ {Column: "{{index .Immutable 0}}", Op: OpEq, Values: []string{"1"}} }
+
+	for _, name := range []string{ {{- range $i, $c := .Immutable}}{{if $i}}, {{end}}"{{$c}}"{{end -}} } {
+		var se ServiceError
+		if _, err := svc.UpdateWhere(ctx, filters, map[string]interface{}{name: 1}); !errors.As(err, &se) || se.Code != 400 {
+			t.Errorf("批量更新 %s 返回 %v, 期望 400 错误", name, err)
+		}
+	}
+}
+{{- end}}
+{{- if .Tenant}}
+
+func Test{{.ModelName}}TenantIsolation(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+
+	other := WithTenant({{if .ShardFunc}}ctx{{else}}context.Background(){{end}}, {{.Tenant.Other}})
+	if _, err := svc.GetByID(other, {{.KeyArgs}}); !IsNotFound(err) {
+		t.Errorf("其他租户 GetByID 返回 %v, 期望记录不存在", err)
+	}
+	if _, err := svc.GetByID({{if .ShardFunc}}WithShardKey(context.Background(), 0){{else}}context.Background(){{end}}, {{.KeyArgs}}); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("缺少租户 GetByID 返回 %v, 期望 ErrMissingTenant", err)
+	}
+}
+{{- end}}
+{{- if .ShardFunc}}
+
+func Test{{.ModelName}}ShardKeyRequired(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+
+	if _, err := svc.GetByID({{if .Tenant}}WithTenant(context.Background(), {{.Tenant.Value}}){{else}}context.Background(){{end}}, {{.KeyArgs}}); !errors.Is(err, ErrMissingShardKey) {
+		t.Errorf("缺少分片键 GetByID 返回 %v, 期望 ErrMissingShardKey", err)
+	}
+}
+{{- end}}
+{{- end}}
+
+func Test{{.ModelName}}List(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	for n := 1; n <= 3; n++ {
+		create{{.ModelName}}Fixture(t, svc, ctx, n)
+	}
+
+	items, total, err := svc.List(ctx, ListQuery{Page: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("List 失败: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("total = %d, 期望 3", total)
+	}
+	if len(items) != 2 {
+		t.Errorf("len(items) = %d, 期望 2", len(items))
+	}
+}
+{{- if .Cursor}}
+
+func Test{{.ModelName}}ListByCursor(t *testing.T) {
+	svc, ctx := new{{.ModelName}}TestService(t)
+	for n := 1; n <= 3; n++ {
+		create{{.ModelName}}Fixture(t, svc, ctx, n)
+	}
+
+	items, page, err := svc.ListByCursor(ctx, CursorQuery{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListByCursor 失败: %v", err)
+	}
+	if len(items) != 2 || page.NextCursor == "" {
+		t.Fatalf("第一页 len(items) = %d, next_cursor = %q, 期望 2 条且有下一页", len(items), page.NextCursor)
+	}
+
+	items, page, err = svc.ListByCursor(ctx, CursorQuery{Cursor: page.NextCursor, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListByCursor 失败: %v", err)
+	}
+	if len(items) != 1 || page.NextCursor != "" {
+		t.Errorf("第二页 len(items) = %d, next_cursor = %q, 期望 1 条且没有下一页", len(items), page.NextCursor)
+	}
+}
+{{- end}}
+{{- if .Search}}
+
+func Test{{.ModelName}}Search(t *testing.T) {
+	{{- if .Search.FullText}}
+	t.Skip("全文索引搜索依赖 MySQL 的 MATCH ... AGAINST，SQLite 不支持")
+	{{- else}}
+	svc, ctx := new{{.ModelName}}TestService(t)
+	created := create{{.ModelName}}Fixture(t, svc, ctx, 1)
+	create{{.ModelName}}Fixture(t, svc, ctx, 2)
+
+	items, total, err := svc.Search(ctx, created.{{.Search.GoName}}, 1, 10)
+	if err != nil {
+		t.Fatalf("Search 失败: %v", err)
+	}
+	if total < 1 || len(items) < 1 {
+		t.Errorf("Search 返回 %d 条（total %d），期望至少 1 条", len(items), total)
+	}
+	{{- end}}
+}
+{{- end}}
+{{- range .UniqueFields}}
+
+func Test{{$.ModelName}}GetBy{{.GoName}}(t *testing.T) {
+	svc, ctx := new{{$.ModelName}}TestService(t)
+	created := create{{$.ModelName}}Fixture(t, svc, ctx, 1)
+	create{{$.ModelName}}Fixture(t, svc, ctx, 2)
+
+	got, err := svc.GetBy{{.GoName}}(ctx, created.{{.GoName}})
+	if err != nil {
+		t.Fatalf("GetBy{{.GoName}} 失败: %v", err)
+	}
+	if got.{{.GoName}} != created.{{.GoName}} {
+		t.Errorf("{{.GoName}} = %v, 期望 %v", got.{{.GoName}}, created.{{.GoName}})
+	}
+}
+{{- end}}
+`
+
+	var pk map[string]interface{}
+	keys := g.getPrimaryKeyFields(table)
+	if len(keys) > 0 {
+		pk = g.primaryKeyTemplateData(table, keys)
+	}
+	// 主键实参取自测试中创建的记录 created
+	var keyArgs []string
+	for _, key := range keys {
+		keyArgs = append(keyArgs, "created."+key["GoName"].(string))
+	}
+	version := g.getVersionColumn(table)
+	tenant := g.getTenantColumn(table)
+	fixtures, needTime := g.getTestFixtures(table, version)
+
+	// 唯一字段查询只测试非指针类型，可与查询参数直接比较
+	var uniqueFields []map[string]interface{}
+	for _, field := range g.getUniqueFields(table.Columns) {
+		if !strings.HasPrefix(field["GoType"].(string), "*") {
+			uniqueFields = append(uniqueFields, field)
+		}
+	}
+
+	data := map[string]interface{}{
+		"ModelPackage": g.config.ModelImportPath,
+		"ServiceName":  g.modelName(table) + "Service",
+		"ModelName":    g.modelName(table),
+		"ModelType":    g.modelPackageName() + "." + g.modelName(table),
+		"FixtureFunc":  g.toLowerCamelCase(g.modelName(table)) + "Fixture",
+		"Comment":      table.Comment,
+		"PK":           pk,
+		"KeyArgs":      strings.Join(keyArgs, ", "),
+		"Fixtures":     fixtures,
+		"Check":        g.getTestCheckField(table, version, tenant),
+		"Version":      version,
+		"Tenant":       g.getTestTenant(tenant),
+		"Immutable":    g.getImmutableColumns(table, version, tenant),
+		"Cursor":       len(g.getCursorKeys(table)) > 0,
+		"Search":       g.getTestSearch(table),
+		"UniqueFields": uniqueFields,
+		"NeedTime":     needTime,
+		"NeedErrors":   pk != nil && (!table.IsView || tenant != nil || len(table.Shards) > 0),
+		"ReadOnly":     table.IsView,
+		"ShardFunc":    g.shardFunc(table),
+		"TestShard":    g.testShard(table),
+	}
+
+	t, err := template.New("service_test").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	fileName := g.fileName(table) + "_service_test.go"
+	return writeGoFile(filepath.Join(g.config.ServiceOutput, fileName), t, data)
+}
+
+// getTestFixtures 根据字段类型生成测试数据表达式，n 为测试数据序号；
+// 自增主键与 BaseModel 字段由数据库填充，版本字段从 1 开始
+func (g *Generator) getTestFixtures(table TableInfo, version map[string]interface{}) ([]map[string]interface{}, bool) {
+	var result []map[string]interface{}
+	needTime := false
+	for _, col := range g.modelColumns(table) {
+		// 自定义类型的 JSON 字段保持零值，测试不依赖用户定义的类型
+		if col.IsAutoIncr || (col.JSON != nil && col.JSON.Custom) {
+			continue
+		}
+		goName := g.fieldName(table, col.Name)
+		value := g.testValue(col, "n")
+		if version != nil && goName == version["GoName"] {
+			value = "1"
+		}
+		if strings.TrimPrefix(col.GoType, "*") == "time.Time" {
+			needTime = true
+		}
+		if strings.HasPrefix(col.GoType, "*") {
+			value = "ptr(" + value + ")"
+		}
+		result = append(result, map[string]interface{}{
+			"GoName": goName,
+			"Value":  value,
+		})
+	}
+	return result, needTime
+}
+
+// getTestInvalidEnums 获取创建接口校验失败测试使用的枚举与集合字段：Invalid 为不在取值范围内的值，
+// Required 表示非空且不接受空字符串的枚举字段，未设置时同样应被拒绝
+func (g *Generator) getTestInvalidEnums(table TableInfo) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		if col.Enum == nil {
+			continue
+		}
+		invalid := "invalid"
+		values := make([]string, 0, len(col.Enum.Values))
+		for _, v := range col.Enum.Values {
+			values = append(values, v.Value)
+		}
+		for contains(values, invalid) {
+			invalid += "_"
+		}
+		literal := strconv.Quote(invalid)
+		if col.Enum.Set {
+			literal = g.modelPackageName() + "." + col.Enum.TypeName + "{" + literal + "}"
+		}
+		result = append(result, map[string]interface{}{
+			"GoName":   g.columnGoName(col),
+			"Invalid":  literal,
+			"Required": !col.IsNullable && !col.Enum.Set && !contains(values, ""),
+		})
+	}
+	return result
+}
+
+// testValue 生成字段的测试值表达式（不含指针），n 为序号表达式；枚举与集合使用第一个取值，JSON 字段使用空对象
+func (g *Generator) testValue(col ColumnInfo, n string) string {
+	if col.Enum != nil {
+		value := g.modelPackageName() + "." + col.Enum.Values[0].Const
+		if col.Enum.Set {
+			return g.modelPackageName() + "." + col.Enum.TypeName + "{" + value + "}"
+		}
+		return value
+	}
+	if col.JSON != nil {
+		return `[]byte("{}")`
+	}
+	switch strings.TrimPrefix(col.GoType, "*") {
+	case "int":
+		return n
+	case "int64":
+		return "int64(" + n + ")"
+	case "uint":
+		return "uint(" + n + ")"
+	case "float64":
+		return "float64(" + n + ") + 0.5"
+	case "bool":
+		return n + "%2 == 1"
+	case "time.Time":
+		return "time.Date(2024, 1, " + n + ", 0, 0, 0, 0, time.UTC)"
+	case "[]byte":
+		return fmt.Sprintf(`[]byte(testString(%q, %s))`, col.Name+"-", n)
+	default:
+		return fmt.Sprintf(`testString(%q, %s)`, col.Name+"-", n)
+	}
+}
+
+// getTestCheckField 选择 Update 测试中修改并校验的字段：非主键、非租户、非版本、非时间戳的可比较字段
+func (g *Generator) getTestCheckField(table TableInfo, version, tenant map[string]interface{}) map[string]interface{} {
+	for _, col := range g.modelColumns(table) {
+		if col.IsPrimaryKey || contains(baseModelColumns, col.Name) {
+			continue
+		}
+		goName := g.fieldName(table, col.Name)
+		if (version != nil && goName == version["GoName"]) || (tenant != nil && goName == tenant["GoName"]) {
+			continue
+		}
+		switch col.GoType {
+		case "string", "int", "int64", "float64":
+			return map[string]interface{}{
+				"GoName":  goName,
+				"Updated": g.testValue(col, "100"),
+			}
+		}
+	}
+	return nil
+}
+
+// getTestTenant 生成多租户测试使用的租户 ID
+func (g *Generator) getTestTenant(tenant map[string]interface{}) map[string]interface{} {
+	if tenant == nil {
+		return nil
+	}
+	result := map[string]interface{}{"Value": "int64(1)", "Other": "int64(2)", "Func": tenant["Func"], "GoName": tenant["GoName"]}
+	if tenant["Func"] == "TenantString" {
+		result["Value"], result["Other"] = `"tenant-1"`, `"tenant-2"`
+	}
+	return result
+}
+
+// getTestSearch 获取搜索测试使用的关键词字段：第一个非指针字符串搜索字段；使用全文索引时 SQLite 无法测试
+func (g *Generator) getTestSearch(table TableInfo) map[string]interface{} {
+	fields := g.getSearchFields(table)
+	if len(fields) == 0 {
+		return nil
+	}
+	if clause, _, _ := g.buildSearchClause(table, fields); strings.Contains(clause, "MATCH") {
+		return map[string]interface{}{"FullText": true}
+	}
+	for _, name := range fields {
+		col, ok := findColumn(table.Columns, name)
+		if ok && col.GoType == "string" {
+			return map[string]interface{}{"GoName": g.fieldName(table, name)}
+		}
+	}
+	return nil
+}
+
+// generateRouterTestBase 生成 Router 测试公共文件
+func (g *Generator) generateRouterTestBase() error {
+	tmpl := `package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB 打开内存 SQLite 数据库并迁移模型，测试结束时自动关闭
+func openTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{TranslateError: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取数据库连接失败: %v", err)
+	}
+	// 内存数据库的每个连接相互独立，限制为单连接以共享同一数据库
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("迁移模型失败: %v", err)
+	}
+	return db
+}
+
+// newTestEngine 创建测试用的 gin 引擎
+func newTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+// ptr 返回值的指针，用于可空字段的测试数据
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// testString 生成带序号的字符串测试数据
+func testString(prefix string, n int) string {
+	return prefix + strconv.Itoa(n)
+}
+
+// keyPath 将主键值拼接为路径参数
+func keyPath(values ...interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = url.PathEscape(fmt.Sprint(v))
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// testResponse 解析后的统一响应，Status 为 HTTP 状态码
+type testResponse struct {
+	Status  int             ` + "`json:\"-\"`" + `
+	Code    int             ` + "`json:\"code\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Data    json.RawMessage ` + "`json:\"data\"`" + `
+}
+
+// doRequest 发送请求并解析统一响应，body 为字符串时原样发送，否则编码为 JSON
+func doRequest(t *testing.T, r http.Handler, method, path string, body interface{}) testResponse {
+	t.Helper()
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("编码请求体失败: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := testResponse{Status: w.Code}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s 响应不是统一响应结构: %v, body: %s", method, path, err, w.Body.String())
+	}
+	return resp
+}
+
+// expectCode 校验响应码；UseHTTPStatus 为 false 时 HTTP 状态码始终为 200
+func expectCode(t *testing.T, resp testResponse, code int) {
+	t.Helper()
+	if resp.Code != code {
+		t.Fatalf("code = %d (%s), 期望 %d", resp.Code, resp.Message, code)
+	}
+	status := http.StatusOK
+	if UseHTTPStatus {
+		status = code
+	}
+	if resp.Status != status {
+		t.Errorf("HTTP 状态码 = %d, 期望 %d", resp.Status, status)
+	}
+}
+
+// decodeData 解析响应中的 data
+func decodeData(t *testing.T, resp testResponse, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(resp.Data, v); err != nil {
+		t.Fatalf("解析 data 失败: %v, data: %s", err, resp.Data)
+	}
+}
+`
+
+	return writeGoFile(filepath.Join(g.config.RouterOutput, "router_test.go"), template.Must(template.New("router_test_base").Parse(tmpl)), nil)
+}
+
+// generateTableRouterTest 生成表 Router 的测试：通过 httptest 请求 SQLite 支撑的路由，校验状态码与统一响应结构
+func (g *Generator) generateTableRouterTest(table TableInfo) error {
+	tmpl := `package router
+
+import (
+	"context"
+	"testing"
+	{{- if .NeedTime}}
+	"time"
+	{{- end}}
+
+	"{{.ModelPackage}}"
+	"{{.ServicePackage}}"
+	"github.com/gin-gonic/gin"
+	{{- if .ReadOnly}}
+	"gorm.io/gorm"
+	{{- end}}
+)
+
+// new{{.ModelName}}TestRouter 创建注册了{{.Comment}}路由的 gin 引擎，返回用于准备数据的{{if .ReadOnly}}数据库连接{{else}} Service {{end}}与 context
+func new{{.ModelName}}TestRouter(t *testing.T) (*gin.Engine, {{if .ReadOnly}}*gorm.DB{{else}}*services.{{.ServiceName}}{{end}}, context.Context) {
+	t.Helper()
+	db := openTestDB(t, &{{.ModelType}}{})
+	svc := services.New{{.ServiceName}}().WithTx(db)
+	ctx := context.Background()
+
+	r := newTestEngine()
+	{{- if .ShardFunc}}
+	{{- if .TestShard}}
+	// 分表后缀不连续，没有默认分表函数：测试中固定使用第一张分表
+	{{.ShardFunc}} = func(int64) string { return "{{.TestShard}}" }
+	t.Cleanup(func() { {{.ShardFunc}} = nil })
+	{{- end}}
+	// 分表：在分片键 0 对应的分表中建表，中间件将分片键写入请求 context
+	if err := db.Table({{.ShardFunc}}(0)).AutoMigrate(&{{.ModelType}}{}); err != nil {
+		t.Fatalf("迁移分表失败: %v", err)
+	}
+	ctx = services.WithShardKey(ctx, 0)
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(services.WithShardKey(c.Request.Context(), 0))
+	})
+	{{- end}}
+	{{- if .Tenant}}
+	ctx = services.WithTenant(ctx, {{.Tenant.Value}})
+	r.Use(func(c *gin.Context) { c.Set(TenantContextKey, {{.Tenant.Value}}) })
+	{{- end}}
+	register{{.ModelName}}Routes(&r.RouterGroup, New{{.HandlerName}}WithService(svc), newRouteOptions(nil))
+	return r, {{.Store}}, ctx
+}
+
+// {{.FixtureFunc}} 按序号生成{{.Comment}}测试数据，不同序号的唯一字段与主键互不相同
+func {{.FixtureFunc}}(n int) {{.ModelType}} {
+	return {{.ModelType}}{
+		{{- range .Fixtures}}
+		{{.GoName}}: {{.Value}},
+		{{- end}}
+	}
+}
+
+{{- if .ReadOnly}}
+
+// seed{{.ModelName}} 直接写入序号为 n 的{{.Comment}}测试数据（视图的 Service 只读）
+func seed{{.ModelName}}(t *testing.T, db *gorm.DB, ctx context.Context, n int) *{{.ModelType}} {
+	t.Helper()
+	m := {{.FixtureFunc}}(n)
+	{{- if .Tenant}}
+	tenantID, err := services.{{.Tenant.Func}}(ctx)
+	if err != nil {
+		t.Fatalf("获取租户失败: %v", err)
+	}
+	m.{{.Tenant.GoName}} = tenantID
+	{{- end}}
+	{{- if .ShardFunc}}
+	db = db.WithContext(ctx).Scopes(services.ShardScope(ctx, {{.ShardFunc}}))
+	{{- end}}
+	if err := db.Create(&m).Error; err != nil {
+		t.Fatalf("Create 失败: %v", err)
+	}
+	return &m
+}
+{{- else}}
+
+// seed{{.ModelName}} 通过 Service 创建序号为 n 的{{.Comment}}测试数据
+func seed{{.ModelName}}(t *testing.T, svc *services.{{.ServiceName}}, ctx context.Context, n int) *{{.ModelType}} {
+	t.Helper()
+	m := {{.FixtureFunc}}(n)
+	if err := svc.Create(ctx, &m); err != nil {
+		t.Fatalf("Create 失败: %v", err)
+	}
+	return &m
+}
+{{- end}}
+{{- if .Ops.create}}
+
+func Test{{.ModelName}}RouteCreate(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "POST", "{{.Path}}", {{.FixtureFunc}}(1))
+	expectCode(t, resp, 200)
+	{{- if .Check}}
+	var got {{.ModelType}}
+	decodeData(t, resp, &got)
+	if want := {{.FixtureFunc}}(1); got.{{.Check.GoName}} != want.{{.Check.GoName}} {
+		t.Errorf("{{.Check.GoName}} = %v, 期望 %v", got.{{.Check.GoName}}, want.{{.Check.GoName}})
+	}
+	{{- end}}
+}
+
+func Test{{.ModelName}}RouteCreateInvalidBody(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "POST", "{{.Path}}", "{invalid")
+	expectCode(t, resp, 400)
+}
+{{- range .InvalidEnums}}
+
+func Test{{$.ModelName}}RouteCreateInvalid{{.GoName}}(t *testing.T) {
+	r, _, _ := new{{$.ModelName}}TestRouter(t)
+
+	m := {{$.FixtureFunc}}(1)
+	m.{{.GoName}} = {{.Invalid}}
+	resp := doRequest(t, r, "POST", "{{$.Path}}", m)
+	expectCode(t, resp, 422)
+}
+{{- if .Required}}
+
+func Test{{$.ModelName}}RouteCreateMissing{{.GoName}}(t *testing.T) {
+	r, _, _ := new{{$.ModelName}}TestRouter(t)
+
+	m := {{$.FixtureFunc}}(1)
+	m.{{.GoName}} = ""
+	resp := doRequest(t, r, "POST", "{{$.Path}}", m)
+	expectCode(t, resp, 422)
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Ops.get}}
+
+func Test{{.ModelName}}RouteGet(t *testing.T) {
+	r, {{.Store}}, ctx := new{{.ModelName}}TestRouter(t)
+	created := seed{{.ModelName}}(t, {{.Store}}, ctx, 1)
+
+	resp := doRequest(t, r, "GET", "{{.Path}}"+keyPath({{.KeyArgs}}), nil)
+	expectCode(t, resp, 200)
+	{{- if .Check}}
+	var got {{.ModelType}}
+	decodeData(t, resp, &got)
+	if got.{{.Check.GoName}} != created.{{.Check.GoName}} {
+		t.Errorf("{{.Check.GoName}} = %v, 期望 %v", got.{{.Check.GoName}}, created.{{.Check.GoName}})
+	}
+	{{- end}}
+}
+
+func Test{{.ModelName}}RouteGetNotFound(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "GET", "{{.Path}}{{.MissingPath}}", nil)
+	expectCode(t, resp, 404)
+}
+{{- if .BadPath}}
+
+func Test{{.ModelName}}RouteGetBadID(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "GET", "{{.Path}}{{.BadPath}}", nil)
+	expectCode(t, resp, 400)
+}
+{{- end}}
+{{- end}}
+{{- if .Ops.batch_create}}
+
+func Test{{.ModelName}}RouteBatchCreateEmpty(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "POST", "{{.Path}}/batch", []{{.ModelType}}{})
+	expectCode(t, resp, 400)
+}
+{{- end}}
+{{- if .Ops.search}}
+
+func Test{{.ModelName}}RouteSearchEmptyKeyword(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "GET", "{{.Path}}/search?keyword=", nil)
+	expectCode(t, resp, 400)
+}
+{{- end}}
+{{- if .Ops.list}}
+
+func Test{{.ModelName}}RouteList(t *testing.T) {
+	r, {{.Store}}, ctx := new{{.ModelName}}TestRouter(t)
+	for n := 1; n <= 3; n++ {
+		seed{{.ModelName}}(t, {{.Store}}, ctx, n)
+	}
+
+	resp := doRequest(t, r, "GET", "{{.Path}}?page=1&page_size=2", nil)
+	expectCode(t, resp, 200)
+	var page struct {
+		List       []{{.ModelType}} ` + "`json:\"list\"`" + `
+		{{- if .Cursor}}
+		NextCursor string ` + "`json:\"next_cursor\"`" + `
+		{{- else}}
+		Total      int64 ` + "`json:\"total\"`" + `
+		{{- end}}
+	}
+	decodeData(t, resp, &page)
+	if len(page.List) != 2 {
+		t.Errorf("len(list) = %d, 期望 2", len(page.List))
+	}
+	{{- if .Cursor}}
+	if page.NextCursor == "" {
+		t.Error("next_cursor 为空，期望有下一页")
+	}
+	{{- else}}
+	if page.Total != 3 {
+		t.Errorf("total = %d, 期望 3", page.Total)
+	}
+	{{- end}}
+}
+{{- end}}
+{{- if .Ops.update}}
+
+func Test{{.ModelName}}RouteUpdate(t *testing.T) {
+	r, svc, ctx := new{{.ModelName}}TestRouter(t)
+	created := seed{{.ModelName}}(t, svc, ctx, 1)
+
+	body := {{.ModelType}}{
+		{{- if .UpdateCheck}}
+		{{.UpdateCheck.GoName}}: {{.UpdateCheck.Updated}},
+		{{- end}}
+		{{- if .Version}}
+		{{.Version.GoName}}: created.{{.Version.GoName}},
+		{{- end}}
+	}
+	resp := doRequest(t, r, "PUT", "{{.Path}}"+keyPath({{.KeyArgs}}), body)
+	expectCode(t, resp, 200)
+	{{- if .UpdateCheck}}
+	var got {{.ModelType}}
+	decodeData(t, resp, &got)
+	if got.{{.UpdateCheck.GoName}} != body.{{.UpdateCheck.GoName}} {
+		t.Errorf("{{.UpdateCheck.GoName}} = %v, 期望 %v", got.{{.UpdateCheck.GoName}}, body.{{.UpdateCheck.GoName}})
+	}
+	{{- end}}
+}
+
+func Test{{.ModelName}}RouteUpdateInvalidBody(t *testing.T) {
+	r, svc, ctx := new{{.ModelName}}TestRouter(t)
+	created := seed{{.ModelName}}(t, svc, ctx, 1)
+
+	resp := doRequest(t, r, "PUT", "{{.Path}}"+keyPath({{.KeyArgs}}), "{invalid")
+	expectCode(t, resp, 400)
+}
+
+func Test{{.ModelName}}RouteUpdateNotFound(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "PUT", "{{.Path}}{{.MissingPath}}", {{.FixtureFunc}}(1))
+	expectCode(t, resp, 404)
+}
+{{- end}}
+{{- if .Ops.delete}}
+
+func Test{{.ModelName}}RouteDelete(t *testing.T) {
+	r, svc, ctx := new{{.ModelName}}TestRouter(t)
+	created := seed{{.ModelName}}(t, svc, ctx, 1)
+
+	resp := doRequest(t, r, "DELETE", "{{.Path}}"+keyPath({{.KeyArgs}}), nil)
+	expectCode(t, resp, 200)
+	if _, err := svc.GetByID(ctx, {{.KeyArgs}}); !services.IsNotFound(err) {
+		t.Errorf("删除后 GetByID 返回 %v, 期望记录不存在", err)
+	}
+}
+{{- if .BadPath}}
+
+func Test{{.ModelName}}RouteDeleteBadID(t *testing.T) {
+	r, _, _ := new{{.ModelName}}TestRouter(t)
+
+	resp := doRequest(t, r, "DELETE", "{{.Path}}{{.BadPath}}", nil)
+	expectCode(t, resp, 400)
+}
+{{- end}}
+{{- end}}
+`
+
+	keys := g.getPrimaryKeyFields(table)
+	ops := g.getOperations(table, len(keys) > 0, len(g.getSearchFields(table)) > 0)
+	version := g.getVersionColumn(table)
+	tenant := g.getTenantColumn(table)
+	fixtures, needTime := g.getTestFixtures(table, version)
+
+	// 主键路径：已创建记录的主键、不存在的主键与无法解析的主键
+	var keyArgs, missing, bad []string
+	hasIntKey := false
+	for _, key := range keys {
+		keyArgs = append(keyArgs, "created."+key["GoName"].(string))
+		if key["GoType"] == "string" {
+			missing = append(missing, "/missing")
+			bad = append(bad, "/x")
+		} else {
+			missing = append(missing, "/999999")
+			bad = append(bad, "/abc")
+			hasIntKey = true
+		}
+	}
+	badPath := ""
+	if hasIntKey {
+		badPath = strings.Join(bad, "")
+	}
+
+	// 更新接口只复制非零值的可编辑字段，校验字段需从中选择
+	var updateCheck map[string]interface{}
+	if check := g.getTestCheckField(table, version, tenant); check != nil {
+		for _, field := range g.getEditableFields(table) {
+			if field["GoName"] == check["GoName"] {
+				updateCheck = check
+				break
+			}
+		}
+	}
+
+	// 视图的 Service 只读，测试数据直接写入数据库
+	store := "svc"
+	if table.IsView {
+		store = "db"
+	}
+
+	data := map[string]interface{}{
+		"ModelPackage":   g.config.ModelImportPath,
+		"ServicePackage": g.config.ServiceImportPath,
+		"ServiceName":    g.modelName(table) + "Service",
+		"HandlerName":    g.modelName(table) + "Handler",
+		"ModelName":      g.modelName(table),
+		"ModelType":      g.modelPackageName() + "." + g.modelName(table),
+		"FixtureFunc":    g.toLowerCamelCase(g.modelName(table)) + "Fixture",
+		"Comment":        table.Comment,
+		"Path":           "/" + g.routePath(table),
+		"KeyArgs":        strings.Join(keyArgs, ", "),
+		"MissingPath":    strings.Join(missing, ""),
+		"BadPath":        badPath,
+		"Ops":            ops,
+		"Fixtures":       fixtures,
+		"Check":          g.getTestCheckField(table, version, tenant),
+		"InvalidEnums":   g.getTestInvalidEnums(table),
+		"UpdateCheck":    updateCheck,
+		"Version":        version,
+		"Tenant":         g.getTestTenant(tenant),
+		"ShardFunc":      g.shardFunc(table),
+		"TestShard":      g.testShard(table),
+		"Cursor":         len(g.getCursorKeys(table)) > 0,
+		"ReadOnly":       table.IsView,
+		"Store":          store,
+		"NeedTime":       needTime || (updateCheck != nil && strings.Contains(updateCheck["Updated"].(string), "time.")),
+	}
+
+	t, err := template.New("router_test").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	fileName := g.fileName(table) + "_router_test.go"
+	return writeGoFile(filepath.Join(g.config.RouterOutput, fileName), t, data)
+}
+
+// testShard 分表后缀不连续（没有默认分表函数）时，测试中固定使用的分表名
+func (g *Generator) testShard(table TableInfo) string {
+	if len(table.Shards) == 0 || contiguousShards(table.Shards) {
+		return ""
+	}
+	return table.Shards[0]
+}
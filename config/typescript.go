@@ -0,0 +1,425 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// tsTypes Go 类型到 TypeScript 类型的映射，时间按 JSON 序列化结果使用字符串，[]byte 为 base64 字符串
+var tsTypes = map[string]string{
+	"int":       "number",
+	"int64":     "number",
+	"uint":      "number",
+	"float64":   "number",
+	"string":    "string",
+	"bool":      "boolean",
+	"time.Time": "string",
+	"[]byte":    "string",
+}
+
+// generateTypeScript 生成与模型 JSON 标签一致的 TypeScript 类型和调用 Router 接口的 fetch 客户端
+func (g *Generator) generateTypeScript(tables []TableInfo) error {
+	if err := os.MkdirAll(g.config.TSOutput, 0755); err != nil {
+		return fmt.Errorf("创建 TypeScript 输出目录失败: %w", err)
+	}
+
+	var items []map[string]interface{}
+	for _, table := range tables {
+		items = append(items, g.tsTable(table))
+	}
+	data := map[string]interface{}{
+		"Tables": items,
+	}
+
+	files := map[string]string{
+		"types.ts":  tsTypesTemplate,
+		"client.ts": tsClientTemplate,
+	}
+	for name, tmpl := range files {
+		t, err := template.New(name).Parse(tmpl)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Create(filepath.Join(g.config.TSOutput, name))
+		if err != nil {
+			return err
+		}
+		err = t.Execute(file, data)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("生成 %s 失败: %w", name, err)
+		}
+	}
+	fmt.Println("生成 TypeScript 代码成功")
+
+	return nil
+}
+
+// tsTable 准备单表的 TypeScript 模板数据
+func (g *Generator) tsTable(table TableInfo) map[string]interface{} {
+	var fields []map[string]interface{}
+	if g.useBaseModel(table) {
+		// BaseModel 的 JSON 标签：id、created_at、updated_at，deleted_at 不序列化
+		fields = append(fields,
+			map[string]interface{}{"Name": g.toJSONCase("id"), "Type": "number"},
+			map[string]interface{}{"Name": g.toJSONCase("created_at"), "Type": "string"},
+			map[string]interface{}{"Name": g.toJSONCase("updated_at"), "Type": "string"},
+		)
+	}
+	var enums []map[string]interface{}
+	for _, col := range g.modelColumns(table) {
+		tsType, ok := tsTypes[strings.TrimPrefix(col.GoType, "*")]
+		if !ok {
+			tsType = "unknown"
+		}
+		if col.Enum != nil {
+			// 枚举取值生成字符串字面量联合类型，集合为其数组
+			var values []string
+			for _, v := range col.Enum.Values {
+				literal, _ := json.Marshal(v.Value)
+				values = append(values, string(literal))
+			}
+			enums = append(enums, map[string]interface{}{
+				"Name":    col.Enum.ElemName,
+				"Values":  strings.Join(values, " | "),
+				"Comment": col.Comment,
+			})
+			tsType = col.Enum.ElemName
+			if col.Enum.Set {
+				tsType += "[]"
+			}
+		}
+		// unknown 已包含 null（JSON 字段等无法映射的类型）
+		if col.IsNullable && tsType != "unknown" {
+			tsType += " | null"
+		}
+		fields = append(fields, map[string]interface{}{
+			"Name":    tsPropertyName(g.jsonName(col)),
+			"Type":    tsType,
+			"Comment": col.Comment,
+		})
+	}
+
+	// 主键：路径参数与批量删除的 ids 元素
+	var keys []map[string]interface{}
+	var params, path []string
+	for _, key := range g.getPrimaryKeyFields(table) {
+		name := key["DBName"].(string)
+		param := lowerCamel(name)
+		keys = append(keys, map[string]interface{}{
+			"Name": tsPropertyName(name),
+			"Type": tsTypes[key["GoType"].(string)],
+		})
+		params = append(params, fmt.Sprintf("%s: %s", param, tsTypes[key["GoType"].(string)]))
+		path = append(path, "/${encodeURIComponent(String("+param+"))}")
+	}
+
+	keyType := ""
+	if len(keys) == 1 {
+		keyType = keys[0]["Type"].(string)
+	} else if len(keys) > 1 {
+		keyType = g.modelName(table) + "Key"
+	}
+
+	return map[string]interface{}{
+		"TypeName":  g.modelName(table),
+		"FieldName": lowerCamel(g.pluralName(table)),
+		"RoutePath": g.routePath(table),
+		"Comment":   table.Comment,
+		"Fields":    fields,
+		"Enums":     enums,
+		"Keys":      keys,
+		"KeyParams": strings.Join(params, ", "),
+		"KeyPath":   strings.Join(path, ""),
+		"KeyType":   keyType,
+		"Multiple":  len(keys) > 1,
+		"Ops":       g.getOperations(table, len(keys) > 0, len(g.getSearchFields(table)) > 0),
+		"Cursor":    len(g.getCursorKeys(table)) > 0,
+	}
+}
+
+// tsTypesTemplate 模型接口、统一响应结构与分页参数
+const tsTypesTemplate = `// 由生成器生成，字段名与 Go 模型的 JSON 标签一致
+
+/** 统一响应结构，code 为 200 表示成功 */
+export interface Response<T> {
+  code: number;
+  message: string;
+  data?: T;
+}
+
+/** 过滤条件的值，数组按逗号拼接，用于 in 过滤 */
+export type FilterValue = string | number | boolean | Array<string | number>;
+
+/**
+ * 过滤条件，键为字段名（等于）或 字段名[op]，op 可选 eq、in、gt、gte、lt、lte、like
+ * 例如 { "age[gt]": 18, "status[in]": [1, 2] }
+ */
+export type Filters = Record<string, FilterValue>;
+
+/** 页码分页参数 */
+export interface ListParams {
+  page?: number;
+  page_size?: number;
+  /** 逗号分隔的排序字段，- 前缀表示降序，如 -created_at,name */
+  sort?: string;
+  /** 逗号分隔的返回字段 */
+  fields?: string;
+  /** 为 false 时不统计总数 */
+  with_total?: boolean;
+  filters?: Filters;
+}
+
+/** 页码分页结果 */
+export interface PageResult<T> {
+  list: T[];
+  page: number;
+  page_size: number;
+  total?: number;
+}
+
+/** 游标分页参数 */
+export interface CursorParams {
+  /** 上一页返回的 next_cursor */
+  cursor?: string;
+  page_size?: number;
+  order?: "asc" | "desc";
+  fields?: string;
+  /** 为 true 时额外统计总数 */
+  with_total?: boolean;
+  filters?: Filters;
+}
+
+/** 游标分页结果，next_cursor 为空表示没有下一页 */
+export interface CursorResult<T> {
+  list: T[];
+  next_cursor: string;
+  page_size: number;
+  total?: number;
+}
+
+/** 搜索参数 */
+export interface SearchParams {
+  keyword: string;
+  page?: number;
+  page_size?: number;
+}
+
+/** 删除结果 */
+export interface DeleteResult {
+  message: string;
+}
+{{- range .Tables}}
+{{- range .Enums}}
+
+/** {{.Comment}}{{if .Comment}}的{{end}}取值 */
+export type {{.Name}} = {{.Values}};
+{{- end}}
+
+/** {{.Comment}} */
+export interface {{.TypeName}} {
+{{- range .Fields}}
+{{- if .Comment}}
+  /** {{.Comment}} */
+{{- end}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- if .Multiple}}
+
+/** {{.Comment}}主键 */
+export interface {{.KeyType}} {
+{{- range .Keys}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+{{- end}}
+`
+
+// tsClientTemplate 基于 fetch 的接口客户端，按表生成与 Router 一致的方法
+const tsClientTemplate = `// 由生成器生成，方法与 Router 注册的接口一一对应
+import type {
+  Response,
+  Filters,
+  ListParams,
+  PageResult,
+  CursorParams,
+  CursorResult,
+  SearchParams,
+  DeleteResult,
+{{- range .Tables}}
+{{- if .Ops}}
+  {{.TypeName}},
+{{- if .Multiple}}
+  {{.KeyType}},
+{{- end}}
+{{- end}}
+{{- end}}
+} from "./types";
+
+/** 接口返回的错误，code 为响应中的错误码 */
+export class ApiError extends Error {
+  constructor(public code: number, message: string) {
+    super(message);
+    this.name = "ApiError";
+  }
+}
+
+export interface ClientOptions {
+  /** 接口前缀，即 RegisterAll 所在路由组的地址，如 /api 或 https://example.com/api */
+  baseURL: string;
+  /** 每个请求附带的请求头，可返回 Promise 以便异步获取令牌 */
+  headers?: () => Record<string, string> | Promise<Record<string, string>>;
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | undefined>;
+
+/** 基础客户端，负责拼接地址、解析统一响应结构，code 不为 200 时抛出 ApiError */
+export class ApiClient {
+  constructor(private options: ClientOptions) {}
+
+  async request<T>(method: string, path: string, query?: Query, body?: unknown): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined && value !== "") {
+        search.set(key, String(value));
+      }
+    }
+    const qs = search.toString();
+    const url = this.options.baseURL.replace(/\/$/, "") + path + (qs ? "?" + qs : "");
+    const headers: Record<string, string> = {
+      Accept: "application/json",
+      ...(this.options.headers ? await this.options.headers() : {}),
+    };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+    const doFetch = this.options.fetch ?? fetch;
+    const res = await doFetch(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    let payload: Response<T>;
+    try {
+      payload = (await res.json()) as Response<T>;
+    } catch {
+      throw new ApiError(res.status, res.statusText);
+    }
+    if (payload.code !== 200) {
+      throw new ApiError(payload.code, payload.message);
+    }
+    return payload.data as T;
+  }
+}
+
+/** 将过滤条件展开为查询参数 */
+function filterQuery(filters?: Filters): Query {
+  const query: Query = {};
+  for (const [key, value] of Object.entries(filters ?? {})) {
+    query[key] = Array.isArray(value) ? value.join(",") : value;
+  }
+  return query;
+}
+
+function listQuery(params: ListParams = {}): Query {
+  const { filters, ...rest } = params;
+  return { ...filterQuery(filters), ...rest };
+}
+
+function cursorQuery(params: CursorParams = {}): Query {
+  const { filters, ...rest } = params;
+  return { ...filterQuery(filters), ...rest };
+}
+{{- range .Tables}}
+{{- if .Ops}}
+
+/** {{.Comment}}接口 */
+export function {{.FieldName}}Api(client: ApiClient) {
+  return {
+{{- if .Ops.create}}
+    /** 创建{{.Comment}} */
+    create: (data: Partial<{{.TypeName}}>) =>
+      client.request<{{.TypeName}}>("POST", "/{{.RoutePath}}", undefined, data),
+{{- end}}
+{{- if .Ops.batch_create}}
+    /** 批量创建{{.Comment}}，batchSize 为每批写入条数 */
+    batchCreate: (items: Partial<{{.TypeName}}>[], batchSize?: number) =>
+      client.request<{{.TypeName}}[]>("POST", "/{{.RoutePath}}/batch", { batch_size: batchSize }, items),
+{{- end}}
+{{- if .Ops.list}}
+{{- if .Cursor}}
+    /** 游标分页获取{{.Comment}}列表 */
+    list: (params?: CursorParams) =>
+      client.request<CursorResult<{{.TypeName}}>>("GET", "/{{.RoutePath}}", cursorQuery(params)),
+{{- else}}
+    /** 分页获取{{.Comment}}列表 */
+    list: (params?: ListParams) =>
+      client.request<PageResult<{{.TypeName}}>>("GET", "/{{.RoutePath}}", listQuery(params)),
+{{- end}}
+{{- end}}
+{{- if .Ops.search}}
+    /** 搜索{{.Comment}} */
+    search: (params: SearchParams) =>
+      client.request<PageResult<{{.TypeName}}>>("GET", "/{{.RoutePath}}/search", { ...params }),
+{{- end}}
+{{- if .Ops.get}}
+    /** 获取{{.Comment}} */
+    get: ({{.KeyParams}}) =>
+      client.request<{{.TypeName}}>("GET", ` + "`" + `/{{.RoutePath}}{{.KeyPath}}` + "`" + `),
+{{- end}}
+{{- if .Ops.update}}
+    /** 更新{{.Comment}}，只更新非零值字段 */
+    update: ({{.KeyParams}}, data: Partial<{{.TypeName}}>) =>
+      client.request<{{.TypeName}}>("PUT", ` + "`" + `/{{.RoutePath}}{{.KeyPath}}` + "`" + `, undefined, data),
+{{- end}}
+{{- if .Ops.delete}}
+    /** 删除{{.Comment}} */
+    delete: ({{.KeyParams}}) =>
+      client.request<DeleteResult>("DELETE", ` + "`" + `/{{.RoutePath}}{{.KeyPath}}` + "`" + `),
+{{- end}}
+{{- if .Ops.batch_delete}}
+    /** 根据主键批量删除{{.Comment}} */
+    batchDelete: (ids: {{.KeyType}}[]) =>
+      client.request<DeleteResult>("DELETE", "/{{.RoutePath}}/batch", undefined, { ids }),
+{{- end}}
+  };
+}
+{{- end}}
+{{- end}}
+
+/** 创建包含全部表接口的客户端 */
+export function createApi(options: ClientOptions) {
+  const client = new ApiClient(options);
+  return {
+    client,
+{{- range .Tables}}
+{{- if .Ops}}
+    {{.FieldName}}: {{.FieldName}}Api(client),
+{{- end}}
+{{- end}}
+  };
+}
+`
+
+// tsPropertyName 返回 TypeScript 属性名，不是合法标识符的名称（如以数字开头）加引号
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// resolveViewKeys 为视图推断主键：视图在 INFORMATION_SCHEMA 中没有主键信息，
+// 优先使用 ViewKeys 中配置的列（配置为空列表表示没有主键），其次使用名为 id 的列，
+// 都没有时视图不生成按主键查询的接口
+func (g *Generator) resolveViewKeys(tables []TableInfo) []TableInfo {
+	result := make([]TableInfo, len(tables))
+	for i, table := range tables {
+		result[i] = table
+		if !table.IsView {
+			continue
+		}
+
+		keys, ok := g.config.ViewKeys[table.Name]
+		if !ok {
+			if _, found := findColumn(table.Columns, "id"); found {
+				keys = []string{"id"}
+			}
+		}
+		var primaryKeys []string
+		for _, key := range keys {
+			if _, found := findColumn(table.Columns, key); !found {
+				log.Printf("警告: 视图 %s 配置的主键列 %s 不存在，已忽略", table.Name, key)
+				continue
+			}
+			primaryKeys = append(primaryKeys, key)
+		}
+
+		columns := make([]ColumnInfo, len(table.Columns))
+		for j, col := range table.Columns {
+			col.IsPrimaryKey = contains(primaryKeys, col.Name)
+			// 视图不能写入，主键不按自增处理
+			col.IsAutoIncr = false
+			col.GoTag = g.generateGoTag(col)
+			columns[j] = col
+		}
+		result[i].Columns = columns
+		result[i].PrimaryKeys = primaryKeys
+		if len(primaryKeys) == 0 {
+			log.Printf("警告: 视图 %s 没有主键（可通过 view_keys 配置），不生成按主键查询的接口", table.Name)
+		} else {
+			fmt.Printf("视图 %s 使用主键 %s\n", table.Name, strings.Join(primaryKeys, ", "))
+		}
+	}
+	return result
+}
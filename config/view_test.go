@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveViewKeys(t *testing.T) {
+	g := NewGenerator(&Config{ViewKeys: map[string][]string{
+		"user_scores": {"user_id", "missing"},
+		"user_ids":    {},
+	}})
+	tables := g.resolveViewKeys([]TableInfo{
+		{Name: "users", PrimaryKeys: []string{"id"}, Columns: []ColumnInfo{
+			testColumn(g, "id", "bigint", false, true, true, ""),
+		}},
+		{Name: "active_users", IsView: true, Columns: []ColumnInfo{
+			testColumn(g, "id", "bigint", false, false, true, ""),
+			testColumn(g, "username", "varchar", false, false, false, ""),
+		}},
+		{Name: "user_scores", IsView: true, Columns: []ColumnInfo{
+			testColumn(g, "id", "bigint", false, false, false, ""),
+			testColumn(g, "user_id", "bigint", false, false, false, ""),
+		}},
+		{Name: "user_ids", IsView: true, Columns: []ColumnInfo{
+			testColumn(g, "id", "bigint", false, false, false, ""),
+		}},
+		{Name: "daily_report", IsView: true, Columns: []ColumnInfo{
+			testColumn(g, "day", "varchar", false, false, false, ""),
+		}},
+	})
+
+	want := map[string][]string{
+		"users":        {"id"},
+		"active_users": {"id"},
+		"user_scores":  {"user_id"},
+		"user_ids":     nil,
+		"daily_report": nil,
+	}
+	for _, table := range tables {
+		if !reflect.DeepEqual(table.PrimaryKeys, want[table.Name]) {
+			t.Errorf("%s 的主键 = %v, 期望 %v", table.Name, table.PrimaryKeys, want[table.Name])
+		}
+		for _, col := range table.Columns {
+			if col.IsPrimaryKey != contains(want[table.Name], col.Name) {
+				t.Errorf("%s.%s IsPrimaryKey = %v", table.Name, col.Name, col.IsPrimaryKey)
+			}
+			if col.IsPrimaryKey != strings.Contains(col.GoTag, "primarykey") {
+				t.Errorf("%s.%s 的标签 %s 与主键不一致", table.Name, col.Name, col.GoTag)
+			}
+		}
+	}
+	if tables[1].Columns[0].IsAutoIncr {
+		t.Error("视图的列不应按自增处理")
+	}
+}
+
+func TestViewOperations(t *testing.T) {
+	g := NewGenerator(&Config{})
+	ops := g.getOperations(TableInfo{Name: "active_users", IsView: true}, true, true)
+	for _, op := range operationAliases["write"] {
+		if ops[op] {
+			t.Errorf("视图不应生成 %s 操作", op)
+		}
+	}
+	for _, op := range []string{"get", "list", "search"} {
+		if !ops[op] {
+			t.Errorf("视图应生成 %s 操作", op)
+		}
+	}
+}
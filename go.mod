@@ -3,6 +3,15 @@ module github.com/you/generator
 go 1.21
 
 require (
+	github.com/bufbuild/protocompile v0.14.1
 	github.com/go-sql-driver/mysql v1.7.1
+	github.com/jinzhu/inflection v1.0.0
+	github.com/vektah/gqlparser/v2 v2.5.19
+	google.golang.org/protobuf v1.34.2
 	gopkg.in/yaml.v3 v3.0.1
 )
+
+require (
+	github.com/agnivade/levenshtein v1.1.1 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
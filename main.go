@@ -18,15 +18,31 @@ func main() {
 		database        = flag.String("database", "", "数据库名")
 		output          = flag.String("output", "", "输出目录")
 		tables          = flag.String("tables", "", "指定表名，多个表用逗号分隔，为空则生成所有表")
+		include         = flag.String("include", "", "表名包含模式，多个用逗号分隔，支持通配符与 re: 前缀的正则，例如: user_*")
+		exclude         = flag.String("exclude", "", "表名排除模式，多个用逗号分隔，例如: *_bak,tmp_*")
+		views           = flag.String("views", "", "视图处理方式: include、skip 或 only")
 		pkgName         = flag.String("package", "", "生成的包名")
 		configFile      = flag.String("config", "config.yaml", "配置文件路径")
 		generateRouter  = flag.Bool("router", false, "是否生成Router代码")
 		generateService = flag.Bool("service", false, "是否生成Service代码")
 		routerOutput    = flag.String("router-output", "", "Router输出目录")
 		serviceOutput   = flag.String("service-output", "", "Service输出目录")
+		skipContainer   = flag.Bool("skip-container", false, "不生成 Service 容器 services.go（Repos 与 Transaction），gRPC、GraphQL 开启时忽略")
 		modelImport     = flag.String("model-import", "", "模型包导入路径，例如: github.com/your/app/internal/models")
 		serviceImport   = flag.String("service-import", "", "服务包导入路径，例如: github.com/your/app/internal/services")
 		storageImport   = flag.String("storage-import", "", "存储包导入路径根，例如: github.com/your/app/internal/storage")
+		pagination      = flag.String("pagination", "", "列表分页模式: offset 或 cursor")
+		statusMode      = flag.String("status-mode", "", "错误响应状态码策略: envelope 或 http")
+		generateGRPC    = flag.Bool("grpc", false, "是否生成 .proto 与 gRPC 服务端代码")
+		grpcOutput      = flag.String("grpc-output", "", "gRPC 服务端代码输出目录")
+		protoOutput     = flag.String("proto-output", "", ".proto 文件输出目录")
+		protoImport     = flag.String("proto-import", "", "protoc 生成的 Go 代码导入路径，例如: github.com/your/app/internal/pb")
+		generateGraphQL = flag.Bool("graphql", false, "是否生成 GraphQL schema 与 gqlgen 解析器")
+		graphqlOutput   = flag.String("graphql-output", "", "GraphQL 代码输出目录")
+		graphqlImport   = flag.String("graphql-import", "", "GraphQL 解析器包导入路径，例如: github.com/your/app/internal/graph")
+		generateTests   = flag.Bool("tests", false, "是否为生成的 Service 与 Router 生成单元测试（依赖 gorm.io/driver/sqlite）")
+		tsOutput        = flag.String("ts-output", "", "TypeScript 类型与接口客户端输出目录，为空则不生成")
+		jsonCase        = flag.String("json-case", "", "模型 JSON 标签命名风格: snake、camel 或 original")
 		help            = flag.Bool("help", false, "显示帮助信息")
 	)
 	flag.Parse()
@@ -53,6 +69,9 @@ func main() {
 		Output:            *output,
 		Package:           *pkgName,
 		Tables:            *tables,
+		Include:           generator.SplitList(*include),
+		Exclude:           generator.SplitList(*exclude),
+		Views:             *views,
 		GenerateRouter:    *generateRouter,
 		GenerateService:   *generateService,
 		RouterOutput:      *routerOutput,
@@ -60,6 +79,19 @@ func main() {
 		ModelImportPath:   *modelImport,
 		ServiceImportPath: *serviceImport,
 		StorageImportPath: *storageImport,
+		Pagination:        *pagination,
+		StatusMode:        *statusMode,
+		GenerateGRPC:      *generateGRPC,
+		GRPCOutput:        *grpcOutput,
+		ProtoOutput:       *protoOutput,
+		ProtoImportPath:   *protoImport,
+		GenerateGraphQL:   *generateGraphQL,
+		GraphQLOutput:     *graphqlOutput,
+		GraphQLImportPath: *graphqlImport,
+		TSOutput:          *tsOutput,
+		GenerateTests:     *generateTests,
+		SkipContainer:     *skipContainer,
+		JSONCase:          *jsonCase,
 	}
 
 	// 合并配置
@@ -105,6 +137,12 @@ func showHelp() {
 	fmt.Println("        输出目录 (默认: internal/models)")
 	fmt.Println("  -tables string")
 	fmt.Println("        指定表名，多个表用逗号分隔，为空则生成所有表")
+	fmt.Println("  -include string")
+	fmt.Println("        表名包含模式，多个用逗号分隔，支持通配符（user_*）与 re: 前缀的正则（re:^user_\\d+$）")
+	fmt.Println("  -exclude string")
+	fmt.Println("        表名排除模式，多个用逗号分隔，例如: *_bak,tmp_*")
+	fmt.Println("  -views string")
+	fmt.Println("        视图处理方式: include（与表一起生成）、skip 或 only (默认: include)")
 	fmt.Println("  -package string")
 	fmt.Println("        生成的包名 (默认: models)")
 	fmt.Println("  -router")
@@ -115,12 +153,38 @@ func showHelp() {
 	fmt.Println("        Router输出目录")
 	fmt.Println("  -service-output string")
 	fmt.Println("        Service输出目录")
+	fmt.Println("  -skip-container")
+	fmt.Println("        不生成 Service 容器 services.go（Repos、NewRepos 与 Transaction），gRPC、GraphQL 开启时忽略 (默认生成)")
 	fmt.Println("  -model-import string")
 	fmt.Println("        模型包导入路径，例如: github.com/your/app/internal/models")
 	fmt.Println("  -service-import string")
 	fmt.Println("        服务包导入路径，例如: github.com/your/app/internal/services")
 	fmt.Println("  -storage-import string")
 	fmt.Println("        存储包导入路径根，例如: github.com/your/app/internal/storage")
+	fmt.Println("  -pagination string")
+	fmt.Println("        列表分页模式: offset 或 cursor (默认: offset)")
+	fmt.Println("  -status-mode string")
+	fmt.Println("        错误响应状态码策略: envelope 或 http (默认: envelope)")
+	fmt.Println("  -grpc")
+	fmt.Println("        是否生成 .proto 与 gRPC 服务端代码（需要同时生成 Service）")
+	fmt.Println("  -grpc-output string")
+	fmt.Println("        gRPC 服务端代码输出目录 (默认: 模型目录同级的 grpcserver)")
+	fmt.Println("  -proto-output string")
+	fmt.Println("        .proto 文件输出目录 (默认: api/proto)")
+	fmt.Println("  -proto-import string")
+	fmt.Println("        protoc 生成的 Go 代码导入路径，例如: github.com/your/app/internal/pb")
+	fmt.Println("  -graphql")
+	fmt.Println("        是否生成 GraphQL schema 与 gqlgen 解析器（需要同时生成 Service）")
+	fmt.Println("  -graphql-output string")
+	fmt.Println("        GraphQL 代码输出目录 (默认: 模型目录同级的 graph)")
+	fmt.Println("  -graphql-import string")
+	fmt.Println("        GraphQL 解析器包导入路径，例如: github.com/your/app/internal/graph")
+	fmt.Println("  -tests")
+	fmt.Println("        是否为生成的 Service 与 Router 生成单元测试（依赖 gorm.io/driver/sqlite）")
+	fmt.Println("  -ts-output string")
+	fmt.Println("        TypeScript 类型与接口客户端输出目录，为空则不生成")
+	fmt.Println("  -json-case string")
+	fmt.Println("        模型 JSON 标签命名风格: snake、camel 或 original (默认: snake)")
 	fmt.Println("  -config string")
 	fmt.Println("        配置文件路径")
 	fmt.Println("  -help")
@@ -131,4 +195,5 @@ func showHelp() {
 	fmt.Println("  go run main.go -database test_db -tables users,articles -output ./models")
 	fmt.Println("  go run main.go -database test_db -password 123456 -router -service")
 	fmt.Println("  go run main.go -database test_db -password 123456 -router -service -tables users,articles")
+	fmt.Println("  go run main.go -database test_db -include 'user_*' -exclude '*_bak,tmp_*' -views skip")
 }